@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"PA/services"
+	"PA/storage"
+	"PA/utils"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Get Task Attachments godoc
+// @Summary List attachments of a task
+// @Tags Attachments
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Success 200 {array} models.Attachment "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/attachments [get]
+func GetTaskAttachmentsController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	attachments, err := services.GetTaskAttachmentsService(db, taskID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// Upload Task Attachment godoc
+// @Summary Upload an attachment to a task
+// @Description Upload multipart dengan field "file". Ukuran dan tipe file dibatasi (ATTACHMENT_MAX_SIZE, ATTACHMENT_ALLOWED_TYPES), tipe dideteksi dari isi file.
+// @Tags Attachments
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param file formData file true "File"
+// @Success 201 {object} models.Attachment "Attachment uploaded"
+// @Failure 400 {object} utils.Problem "Invalid file"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/attachments [post]
+func UploadTaskAttachmentController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	store := c.MustGet("storage").(storage.Store)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Body dibaca sebagai stream, file tidak pernah dimuat utuh ke memory
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, services.MaxAttachmentSize+1<<20)
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		c.Error(utils.Validation("invalid_request", "body harus berupa multipart/form-data"))
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			c.Error(uploadError(err))
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := services.UploadAttachmentService(db, store, taskID, part.FileName(), part, userID)
+		part.Close()
+		if err != nil {
+			c.Error(uploadError(err))
+			return
+		}
+		c.JSON(http.StatusCreated, attachment)
+		return
+	}
+
+	c.Error(utils.Validation("missing_file", "field file wajib diisi"))
+}
+
+// uploadError mengubah error karena body melebihi batas menjadi error ukuran attachment
+func uploadError(err error) error {
+	var maxBytes *http.MaxBytesError
+	if errors.As(err, &maxBytes) {
+		return services.ErrAttachmentTooLarge
+	}
+	return err
+}
+
+// Download Task Attachment godoc
+// @Summary Download an attachment
+// @Tags Attachments
+// @Security BearerAuth
+// @Produce octet-stream
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param attachment_id path int true "Attachment ID"
+// @Success 200 {file} file "Isi file"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Attachment Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/attachments/{attachment_id} [get]
+func DownloadTaskAttachmentController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	store := c.MustGet("storage").(storage.Store)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	attachmentID, err := parseIDParam(c, "attachment_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	attachment, reader, err := services.OpenAttachmentService(db, store, taskID, attachmentID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer reader.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, reader, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"ETag":                   strconv.Quote(attachment.Checksum),
+	})
+}
+
+// Delete Task Attachment godoc
+// @Summary Delete an attachment
+// @Description Dapat dilakukan oleh pengupload atau owner/admin project.
+// @Tags Attachments
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param attachment_id path int true "Attachment ID"
+// @Success 200 {object} map[string]string "Attachment deleted successfully"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Attachment Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/attachments/{attachment_id} [delete]
+func DeleteTaskAttachmentController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	attachmentID, err := parseIDParam(c, "attachment_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.DeleteAttachmentService(db, taskID, attachmentID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
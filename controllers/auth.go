@@ -18,19 +18,19 @@ import (
 // @Produce json
 // @Param input body models.UserAuth true "Registration Data"
 // @Success 200 {string} string "Success"
-// @Failure 400 {string} string "Bad Request"
-// @Failure 500 {string} string "Internal Server Error"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/register [post]
 func Register(c *gin.Context) {
     var input models.UserAuth
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(utils.Validation("invalid_request", err.Error()))
         return
     }
 
     db := c.MustGet("db").(*gorm.DB)
     if err := services.RegisterService(db, input); err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        c.Error(err)
         return
     }
 
@@ -43,14 +43,14 @@ func Register(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param input body models.UserAuth true "Login"
-// @Success 200 {string} string "Login successful"
-// @Failure 400 {string} string "Bad Request - User not found or invalid password"
-// @Failure 500 {string} string "Internal Server Error"
+// @Success 200 {object} models.AuthTokens "Login successful"
+// @Failure 400 {object} utils.Problem "Bad Request - User not found or invalid password"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/login [post]
 func Login(c *gin.Context) {
     var input models.UserAuth
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(utils.Validation("invalid_request", err.Error()))
         return
     }
 
@@ -59,24 +59,85 @@ func Login(c *gin.Context) {
     var identifier string
     if input.Username != "" {
         if strings.Contains(input.Username, "@") {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Username cannot contain '@'"})
+            c.Error(services.ErrInvalidUsername)
             return
         }
         identifier = input.Username
     } else {
         if !utils.IsValidEmail(input.Email) {
-            c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid email format"})
+            c.Error(services.ErrInvalidEmail)
             return
         }
         identifier = input.Email
     }
 
-    token, err := services.LoginService(db, identifier, input.Password)
+    tokens, err := services.LoginService(db, identifier, input.Password)
     if err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+        c.Error(err)
         return
     }
 
-    c.JSON(http.StatusOK, gin.H{"token": token})
+    c.JSON(http.StatusOK, tokens)
+}
+
+// Refresh Token godoc
+// @Summary Exchange a refresh token for a new token pair
+// @Description Refresh token dirotasi setiap dipakai. Memakai ulang refresh token lama akan mencabut seluruh sesi terkait.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param input body models.RefreshTokenInput true "Refresh Token"
+// @Success 200 {object} models.AuthTokens "Token refreshed"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Invalid, expired or reused refresh token"
+// @Router /api/token/refresh [post]
+func RefreshTokenController(c *gin.Context) {
+    var input models.RefreshTokenInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        c.Error(utils.Validation("invalid_request", err.Error()))
+        return
+    }
+
+    db := c.MustGet("db").(*gorm.DB)
+    tokens, err := services.RefreshTokenService(db, input.RefreshToken)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary Logout and revoke the current session
+// @Tags Auth
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param input body models.LogoutInput false "Set all=true untuk logout dari semua sesi"
+// @Success 200 {string} string "Logout successful"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/logout [post]
+func LogoutController(c *gin.Context) {
+    var input models.LogoutInput
+    if c.Request.ContentLength > 0 {
+        if err := c.ShouldBindJSON(&input); err != nil {
+            c.Error(utils.Validation("invalid_request", err.Error()))
+            return
+        }
+    }
+
+    db := c.MustGet("db").(*gorm.DB)
+    userID := c.MustGet("user_id").(uint)
+    claims := c.MustGet("token_claims").(utils.TokenClaims)
+
+    if err := services.LogoutService(db, userID, claims, input.All); err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, gin.H{"message": "Logout successful"})
 }
 
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"PA/models"
+	"PA/services"
+	"PA/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CommentInput digunakan untuk membuat dan mengedit comment. Tulis @username untuk mention anggota project.
+type CommentInput struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// CommentListResponse adalah response list comment dengan cursor halaman berikutnya
+type CommentListResponse struct {
+	Data       []models.TaskComment `json:"data"`
+	NextCursor string               `json:"next_cursor"`
+}
+
+// Get Task Comments godoc
+// @Summary Get comments of a task
+// @Tags Comments
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param limit query int false "Jumlah item per halaman (default 20, max 100)"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya"
+// @Param sort query string false "Urutan comment, default created (terlama dulu)" Enums(created, -created)
+// @Success 200 {object} CommentListResponse "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/comments [get]
+func GetTaskCommentsController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	comments, next, err := services.GetTaskCommentsService(db, taskID, userID, opts)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, CommentListResponse{Data: comments, NextCursor: next})
+}
+
+// Add Task Comment godoc
+// @Summary Add a comment to a task
+// @Description @username yang merupakan anggota project akan dicatat sebagai mention.
+// @Tags Comments
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param input body CommentInput true "Comment"
+// @Success 201 {object} models.TaskComment "Comment created"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/comments [post]
+func AddTaskCommentController(c *gin.Context) {
+	var input CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	comment, err := services.CreateCommentService(db, taskID, input.Body, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// Update Task Comment godoc
+// @Summary Edit a comment
+// @Description Hanya penulis comment yang bisa mengedit. Isi sebelumnya disimpan di riwayat edit.
+// @Tags Comments
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param comment_id path int true "Comment ID"
+// @Param input body CommentInput true "Comment"
+// @Success 200 {object} models.TaskComment "Comment updated"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Comment Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/comments/{comment_id} [put]
+func UpdateTaskCommentController(c *gin.Context) {
+	var input CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	commentID, err := parseIDParam(c, "comment_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	comment, err := services.UpdateCommentService(db, taskID, commentID, input.Body, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// Delete Task Comment godoc
+// @Summary Delete a comment
+// @Description Penulis dapat menghapus comment miliknya, owner/admin dapat menghapus comment siapa saja.
+// @Tags Comments
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param comment_id path int true "Comment ID"
+// @Success 200 {object} map[string]string "Comment deleted successfully"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Comment Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/comments/{comment_id} [delete]
+func DeleteTaskCommentController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	commentID, err := parseIDParam(c, "comment_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.DeleteCommentService(db, taskID, commentID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+// Get Task Comment History godoc
+// @Summary Get the edit history of a comment
+// @Tags Comments
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param comment_id path int true "Comment ID"
+// @Success 200 {array} models.TaskCommentEdit "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Comment Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/comments/{comment_id}/history [get]
+func GetTaskCommentHistoryController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	commentID, err := parseIDParam(c, "comment_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	edits, err := services.GetCommentHistoryService(db, taskID, commentID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, edits)
+}
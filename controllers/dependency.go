@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"PA/services"
+	"PA/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// DependencyInput digunakan untuk membuat dependency dari sudut pandang task di url
+type DependencyInput struct {
+	Type   string `json:"type" binding:"required" enums:"blocks,blocked_by"`
+	TaskID uint   `json:"task_id" binding:"required"`
+}
+
+// Add Task Dependency godoc
+// @Summary Link a task as blocking or blocked by another task
+// @Description type blocks: task ini memblokir task_id. type blocked_by: task ini diblokir oleh task_id. Task boleh berbeda project, link yang membentuk siklus ditolak.
+// @Tags Dependencies
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param input body DependencyInput true "Dependency"
+// @Success 201 {object} models.TaskDependency "Dependency created"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 409 {object} utils.Problem "Dependency already exists or would create a cycle"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/dependencies [post]
+func AddTaskDependencyController(c *gin.Context) {
+	var input DependencyInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	dependency, err := services.AddDependencyService(db, taskID, input.Type, input.TaskID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dependency)
+}
+
+// Delete Task Dependency godoc
+// @Summary Remove a dependency link of a task
+// @Tags Dependencies
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param dependency_id path int true "Dependency ID"
+// @Success 200 {object} map[string]string "Dependency deleted successfully"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Dependency Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/dependencies/{dependency_id} [delete]
+func DeleteTaskDependencyController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	dependencyID, err := parseIDParam(c, "dependency_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.DeleteDependencyService(db, taskID, dependencyID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dependency deleted successfully"})
+}
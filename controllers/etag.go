@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"PA/services"
+	"PA/utils"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag menulis header ETag berdasarkan version resource
+func setETag(c *gin.Context, version uint) {
+	c.Header("ETag", `"`+strconv.FormatUint(uint64(version), 10)+`"`)
+}
+
+// ifMatchVersion membaca header If-Match yang wajib dikirim pada update dan delete agar perubahan
+// tidak diam-diam menimpa perubahan orang lain. "*" adalah opt-out eksplisit dan menghasilkan 0.
+func ifMatchVersion(c *gin.Context) (uint, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		return 0, services.ErrIfMatchRequired
+	}
+	if value == "*" {
+		return 0, nil
+	}
+	if strings.Contains(value, ",") {
+		return 0, utils.Validation("invalid_if_match", "If-Match hanya mendukung satu ETag")
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	version, err := strconv.ParseUint(strings.Trim(value, `"`), 10, 64)
+	if err != nil || version == 0 {
+		return 0, services.ErrVersionMismatch
+	}
+	return uint(version), nil
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"PA/models"
+	"PA/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TaskEventListResponse adalah response riwayat task dengan cursor halaman berikutnya
+type TaskEventListResponse struct {
+	Data       []models.TaskEvent `json:"data"`
+	NextCursor string             `json:"next_cursor"`
+}
+
+// Get Task History godoc
+// @Summary Get the change history of a task
+// @Description Setiap event berisi actor, waktu dan nilai before/after field yang berubah (changes).
+// @Description Aksi: created, updated, assignees_changed, deleted, restored (dipulihkan dari trash).
+// @Tags Tasks
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param limit query int false "Jumlah item per halaman (default 20, max 100)"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya"
+// @Param sort query string false "Urutan event, default -created (terbaru dulu)" Enums(created, -created)
+// @Success 200 {object} TaskEventListResponse "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/history [get]
+func GetTaskHistoryController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	events, next, err := services.GetTaskHistoryService(db, taskID, userID, opts)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TaskEventListResponse{Data: events, NextCursor: next})
+}
+
+// ActivityListResponse adalah response activity feed project dengan cursor halaman berikutnya
+type ActivityListResponse struct {
+	Data       []models.ActivityItem `json:"data"`
+	NextCursor string                `json:"next_cursor"`
+}
+
+// Get Project Activity godoc
+// @Summary Get the activity feed of a project
+// @Description Gabungan riwayat project (ganti nama, collaborator ditambah/dihapus/diubah role-nya, percobaan hapus)
+// @Description dan riwayat seluruh task project, terbaru dulu, lengkap dengan ringkasan yang mudah dibaca (summary).
+// @Description Guest hanya melihat riwayat task yang di-assign ke dirinya.
+// @Tags Projects
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param limit query int false "Jumlah item per halaman (default 20, max 100)"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya"
+// @Success 200 {object} ActivityListResponse "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/activity [get]
+func GetProjectActivityController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	opts, err := parseListOptions(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	items, next, err := services.GetProjectActivityService(db, currentProject(c), userID, opts)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ActivityListResponse{Data: items, NextCursor: next})
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"PA/models"
+	"PA/services"
+	"PA/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LabelInput digunakan untuk membuat label project
+type LabelInput struct {
+	Name  string `json:"name" binding:"required" example:"bug"`
+	Color string `json:"color" example:"#d73a4a"`
+}
+
+// TaskLabelInput digunakan untuk memasang label ke task
+type TaskLabelInput struct {
+	LabelID uint `json:"label_id" binding:"required"`
+}
+
+// Get Labels godoc
+// @Summary Get all labels of a project
+// @Tags Labels
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Success 200 {array} models.Label "OK"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/labels [get]
+func GetLabelsController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	labels, err := services.GetLabelsService(db, currentProject(c), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": labels})
+}
+
+// Add Label godoc
+// @Summary Create a label in a project
+// @Tags Labels
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param input body LabelInput true "Label"
+// @Success 201 {object} models.Label "Label created"
+// @Failure 400 {object} utils.Problem "Invalid label"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 409 {object} utils.Problem "Label already exists"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/labels [post]
+func AddLabelController(c *gin.Context) {
+	var input LabelInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	label := models.Label{Name: input.Name, Color: input.Color}
+	if err := services.CreateLabelService(db, currentProject(c), &label, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": label})
+}
+
+// Delete Label godoc
+// @Summary Delete a label from a project and all its tasks
+// @Tags Labels
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param label_id path int true "Label ID"
+// @Success 200 {object} map[string]string "Label deleted"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Label Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/labels/{label_id} [delete]
+func DeleteLabelController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	labelID, err := parseIDParam(c, "label_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.DeleteLabelService(db, currentProject(c), labelID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Label deleted successfully"})
+}
+
+// Add Task Label godoc
+// @Summary Attach a project label to a task
+// @Tags Labels
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param input body TaskLabelInput true "Label"
+// @Success 200 {array} models.Label "Labels of the task"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task or label Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/labels [post]
+func AddTaskLabelController(c *gin.Context) {
+	var input TaskLabelInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	labels, err := services.AddTaskLabelService(db, taskID, input.LabelID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": labels})
+}
+
+// Remove Task Label godoc
+// @Summary Detach a label from a task
+// @Tags Labels
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param label_id path int true "Label ID"
+// @Success 200 {array} models.Label "Labels of the task"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task or label Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/labels/{label_id} [delete]
+func RemoveTaskLabelController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	labelID, err := parseIDParam(c, "label_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	labels, err := services.RemoveTaskLabelService(db, taskID, labelID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": labels})
+}
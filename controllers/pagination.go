@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"PA/models"
+	"PA/utils"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TaskListResponse adalah response list task dengan cursor halaman berikutnya
+type TaskListResponse struct {
+	Data       []models.Task `json:"data"`
+	NextCursor string        `json:"next_cursor"`
+}
+
+// ProjectListResponse adalah response list project dengan cursor halaman berikutnya
+type ProjectListResponse struct {
+	Data       []models.Project `json:"data"`
+	NextCursor string           `json:"next_cursor"`
+}
+
+// parseListOptions membaca limit, cursor dan sort dari query string. Awalan "-" pada sort berarti descending.
+func parseListOptions(c *gin.Context) (models.ListOptions, error) {
+	opts := models.ListOptions{Cursor: c.Query("cursor")}
+
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 {
+			return opts, utils.Validation("invalid_limit", "limit harus berupa angka positif")
+		}
+		opts.Limit = value
+	}
+
+	sort := c.Query("sort")
+	if strings.HasPrefix(sort, "-") {
+		opts.Desc = true
+		sort = strings.TrimPrefix(sort, "-")
+	}
+	opts.Sort = sort
+
+	return opts, nil
+}
+
+// parseTaskFilter membaca filter task dari query string
+func parseTaskFilter(c *gin.Context) (models.TaskFilter, error) {
+	filter := models.TaskFilter{
+		Status: c.Query("status"),
+		Query:  c.Query("q"),
+	}
+
+	if assignee := c.Query("assignee"); assignee != "" {
+		value, err := strconv.ParseUint(assignee, 10, 64)
+		if err != nil {
+			return filter, utils.Validation("invalid_assignee", "assignee harus berupa id user")
+		}
+		filter.AssigneeID = uint(value)
+	}
+
+	if sprint := c.Query("sprint"); sprint == "backlog" {
+		filter.Backlog = true
+	} else if sprint != "" {
+		value, err := strconv.ParseUint(sprint, 10, 64)
+		if err != nil {
+			return filter, utils.Validation("invalid_sprint", "sprint harus berupa id sprint atau backlog")
+		}
+		filter.SprintID = uint(value)
+	}
+
+	for name, target := range map[string]**time.Time{
+		"deadline_before": &filter.DeadlineBefore,
+		"deadline_after":  &filter.DeadlineAfter,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseDateParam(value)
+		if err != nil {
+			return filter, utils.Validation("invalid_"+name, name+" harus berformat YYYY-MM-DD atau YYYY-MM-DD HH:MM:SS")
+		}
+		*target = &parsed
+	}
+
+	return filter, nil
+}
+
+// parseDateParam menerima tanggal saja, format deadline, atau RFC3339
+func parseDateParam(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02", time.RFC3339} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -2,10 +2,9 @@ package controllers
 
 import (
 	"net/http"
-	"strconv"
 	"PA/models"
 	"PA/services"
-	"strings"
+	"PA/utils"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
@@ -16,29 +15,65 @@ type ProjectInput struct {
 	Description string `json:"description"`
 }
 
+// CollaboratorInput digunakan untuk validasi input add, update & remove collaborator
+type CollaboratorInput struct {
+	UserID uint `json:"user_id" binding:"required"`
+	Role string `json:"role" enums:"admin,member,viewer,guest"`
+}
+
+// TransferOwnershipInput digunakan untuk memindahkan kepemilikan project. Owner lama tetap menjadi
+// collaborator jika keep_previous_owner true, dengan role previous_owner_role (default admin).
+type TransferOwnershipInput struct {
+	NewOwnerID uint `json:"new_owner_id" binding:"required"`
+	KeepPreviousOwner bool `json:"keep_previous_owner"`
+	PreviousOwnerRole string `json:"previous_owner_role" enums:"admin,member,viewer,guest"`
+}
+
+// currentProject mengambil project yang sudah dimuat oleh middleware LoadProject
+func currentProject(c *gin.Context) *models.Project {
+	return c.MustGet("project").(*models.Project)
+}
+
 
 // Get Projects godoc
-// @Summary Get all projects
+// @Summary Get owned and shared projects
 // @Tags Projects
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer Token"
-// @Success 200 {object} map[string]interface{} "Success"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Param limit query int false "Jumlah item per halaman (default 20, max 100)"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya"
+// @Param sort query string false "created atau name. Awali dengan - untuk descending" Enums(created, -created, name, -name)
+// @Param q query string false "Cari di name dan description"
+// @Param membership query string false "Project yang dimiliki, dibagikan ke user, atau keduanya (default all)" Enums(owned, shared, all)
+// @Param archived query string false "false: hanya project aktif (default), true: hanya project diarsipkan, all: keduanya" Enums(false, true, all)
+// @Success 200 {object} ProjectListResponse "Success"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects [get]
 func GetProjectsController(c *gin.Context) {
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(uint)
 
-	projects, err := services.GetAllProjectsService(db, userID)
+	opts, err := parseListOptions(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		c.Error(err)
 		return
 	}
+	filter := models.ProjectFilter{
+		Query:      c.Query("q"),
+		Membership: c.Query("membership"),
+		Archived:   c.Query("archived"),
+	}
 
-	c.JSON(http.StatusOK, gin.H{"data": projects})
+	projects, next, err := services.GetAllProjectsService(db, userID, filter, opts)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ProjectListResponse{Data: projects, NextCursor: next})
 }
 
 // Get Project by ID godoc
@@ -50,27 +85,17 @@ func GetProjectsController(c *gin.Context) {
 // @Param Authorization header string true "Bearer Token"
 // @Param project_id path uint true "Project ID"
 // @Success 200 {object} models.Project "Success"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 404 {object} map[string]string "Project Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Header 200 {string} ETag "Version project"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects/{project_id} [get]
 func GetProjectByIDController(c *gin.Context) {
-	db := c.MustGet("db").(*gorm.DB)
-	userID := c.MustGet("user_id").(uint)
-	projectIDStr := c.Param("project_id")
-
-	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
-		return
-	}
-
-	project, err := services.GetProjectByIDService(db, uint(projectID), userID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		return
-	}
+	// Akses sudah dicek oleh middleware LoadProject
+	project := currentProject(c)
 
+	setETag(c, project.Version)
 	c.JSON(http.StatusOK, gin.H{"data": project})
 }
 
@@ -83,13 +108,14 @@ func GetProjectByIDController(c *gin.Context) {
 // @Produce json
 // @Param project body ProjectInput true "Project Input"
 // @Success 201 {object} models.Project
-// @Failure 400 {object} map[string]interface{} "Bad Request"
-// @Failure 500 {object} map[string]interface{} "Internal Server Error"
+// @Header 201 {string} ETag "Version project"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects [post]
 func AddProjectController(c *gin.Context) {
 	var input ProjectInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(utils.Validation("invalid_request", err.Error()))
 		return
 	}
 
@@ -103,10 +129,11 @@ func AddProjectController(c *gin.Context) {
 	}
 
 	if err := services.CreateProjectService(db, &project); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Gagal membuat project"})
+		c.Error(err)
 		return
 	}
 
+	setETag(c, project.Version)
 	c.JSON(http.StatusCreated, gin.H{"data": project})
 }
 
@@ -118,92 +145,209 @@ func AddProjectController(c *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Bearer Token"
 // @Param project_id path uint true "Project ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
 // @Param input body ProjectInput true "Project Data"
 // @Success 200 {object} models.Project "Project Updated"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 404 {object} map[string]string "Project Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Header 200 {string} ETag "Version project"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 409 {object} utils.Problem "Project is archived"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects/{project_id} [put]
 func EditProjectController(c *gin.Context) {
 	var input ProjectInput
 	if err := c.ShouldBindJSON(&input); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(utils.Validation("invalid_request", err.Error()))
 		return
 	}
 
 	db := c.MustGet("db").(*gorm.DB)
 	userID := c.MustGet("user_id").(uint)
-	projectIDStr := c.Param("project_id") 
+	project := currentProject(c)
 
-	projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
+	expectedVersion, err := ifMatchVersion(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		c.Error(err)
 		return
 	}
 
-	project, err := services.GetProjectByIDService(db, uint(projectID), userID)
-    if err != nil {
-        errorMsg := err.Error()
-        statusCode := http.StatusNotFound
-        if errorMsg == "anda tidak memiliki akses ke project ini" {
-            statusCode = http.StatusForbidden
-        }
-        c.JSON(statusCode, gin.H{"error": errorMsg})
-        return
-    }
-
 	project.Name = input.Name
 	project.Description = input.Description
 
-	if err := services.UpdateProjectService(db, &project, userID); err != nil {
-        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	if err := services.UpdateProjectService(db, project, expectedVersion, userID); err != nil {
+        c.Error(err)
         return
     }
 
+	setETag(c, project.Version)
+
 	c.JSON(http.StatusOK, gin.H{"data": project})
 }
 
 // Delete Project godoc
 // @Summary Delete a project
+// @Description Project beserta task-nya dipindahkan ke trash dan bisa dipulihkan lewat /api/trash/projects/{project_id}/restore
 // @Tags Projects
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer Token"
 // @Param project_id path uint true "Project ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
 // @Success 200 {object} map[string]string "Success"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 403 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "Project Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Unauthorized"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects/{project_id} [delete]
 func DeleteProjectController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     userID := c.MustGet("user_id").(uint)
-    
-    projectID, err := strconv.ParseUint(c.Param("project_id"), 10, 64)
+    project := currentProject(c)
+
+    expectedVersion, err := ifMatchVersion(c)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+        c.Error(err)
         return
     }
     
-    if err := services.DeleteProjectService(db, uint(projectID), userID); err != nil {
-        errorMsg := gin.H{"error": err.Error()}
-        status := http.StatusInternalServerError
-        
-        if strings.Contains(err.Error(), "unauthorized") {
-            status = http.StatusForbidden
-        } else if strings.Contains(err.Error(), "tidak ditemukan") {
-            status = http.StatusNotFound
-        }
-        
-        c.JSON(status, errorMsg)
+    if err := services.DeleteProjectService(db, project, expectedVersion, userID); err != nil {
+        c.Error(err)
         return
     }
     
     c.JSON(http.StatusOK, gin.H{"message": "Project berhasil dihapus"})
 }
 
+// Archive Project godoc
+// @Summary Archive a project
+// @Description Project yang diarsipkan menjadi read-only: task, collaborator, sprint, label dan workflow tidak bisa diubah sampai project diaktifkan kembali
+// @Tags Projects
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path uint true "Project ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
+// @Success 200 {object} models.Project "Project archived"
+// @Header 200 {string} ETag "Version project"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/archive [post]
+func ArchiveProjectController(c *gin.Context) {
+	setProjectArchived(c, true)
+}
+
+// Unarchive Project godoc
+// @Summary Unarchive a project
+// @Tags Projects
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path uint true "Project ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
+// @Success 200 {object} models.Project "Project unarchived"
+// @Header 200 {string} ETag "Version project"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/unarchive [post]
+func UnarchiveProjectController(c *gin.Context) {
+	setProjectArchived(c, false)
+}
+
+// Transfer Project Ownership godoc
+// @Summary Transfer project ownership to another user
+// @Description Hanya owner saat ini atau admin sistem (ADMIN_USERS); non-anggota yang bukan admin mendapat 404. Jika owner baru sebelumnya collaborator, ia dihapus dari daftar collaborator.
+// @Tags Projects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path uint true "Project ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
+// @Param input body TransferOwnershipInput true "New owner"
+// @Success 200 {object} models.Project "Ownership transferred"
+// @Header 200 {string} ETag "Version project"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project or user Not Found"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/transfer [post]
+func TransferProjectOwnershipController(c *gin.Context) {
+	var input TransferOwnershipInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	projectID, err := parseIDParam(c, "project_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	previousOwnerRole := ""
+	if input.KeepPreviousOwner {
+		previousOwnerRole = input.PreviousOwnerRole
+		if previousOwnerRole == "" {
+			previousOwnerRole = models.RoleAdmin
+		}
+	}
+
+	project, err := services.TransferProjectOwnershipService(db, projectID, input.NewOwnerID, previousOwnerRole, expectedVersion, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, project.Version)
+
+	c.JSON(http.StatusOK, gin.H{"data": project})
+}
+
+func setProjectArchived(c *gin.Context, archived bool) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+	project := currentProject(c)
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.SetProjectArchivedService(db, project, archived, expectedVersion, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, project.Version)
+
+	c.JSON(http.StatusOK, gin.H{"data": project})
+}
+
 // Add Collaborator godoc
 // @Summary Add a collaborator to a project
 // @Tags Projects
@@ -214,39 +358,66 @@ func DeleteProjectController(c *gin.Context) {
 // @Param project_id path uint true "Project ID"
 // @Param input body CollaboratorInput true "Collaborator Data"
 // @Success 200 {object} map[string]string "Collaborator added successfully"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "Project Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects/{project_id}/collaborators [post]
 func AddCollaboratorController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     ownerID := c.MustGet("user_id").(uint)
+    project := currentProject(c)
 
-    projectIDStr := c.Param("project_id")
-    projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
-
-    if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+    var input CollaboratorInput
+    
+    if err := c.ShouldBindJSON(&input); err != nil {
+        c.Error(utils.Validation("invalid_request", err.Error()))
         return
     }
 
-    var input struct {
-        UserID uint `json:"user_id" binding:"required"`
+	// Memanggil service untuk add collaborator, role default member
+    if err := services.AddCollaboratorService(db, project, input.UserID, input.Role, ownerID); err != nil {
+        c.Error(err)
+        return
     }
-    
+
+    c.JSON(http.StatusOK, gin.H{"message": "Collaborator berhasil ditambahkan"})
+}
+
+// Update Collaborator Role godoc
+// @Summary Change the role of a collaborator
+// @Tags Projects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path uint true "Project ID"
+// @Param input body CollaboratorInput true "Collaborator Data"
+// @Success 200 {object} map[string]string "Collaborator role updated"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Collaborator Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/collaborators [put]
+func UpdateCollaboratorRoleController(c *gin.Context) {
+    db := c.MustGet("db").(*gorm.DB)
+    userID := c.MustGet("user_id").(uint)
+    project := currentProject(c)
+
+    var input CollaboratorInput
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(utils.Validation("invalid_request", err.Error()))
         return
     }
 
-	// Memanggil service untuk add collaborator
-    if err := services.AddCollaboratorService(db, uint(projectID), input.UserID, ownerID); err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+    if err := services.UpdateCollaboratorRoleService(db, project, input.UserID, input.Role, userID); err != nil {
+        c.Error(err)
         return
     }
 
-    c.JSON(http.StatusOK, gin.H{"message": "Collaborator berhasil ditambahkan"})
+    c.JSON(http.StatusOK, gin.H{"message": "Role collaborator berhasil diubah"})
 }
 
 // Remove Collaborator godoc
@@ -259,34 +430,26 @@ func AddCollaboratorController(c *gin.Context) {
 // @Param project_id path uint true "Project ID"
 // @Param input body CollaboratorInput true "Collaborator Data"
 // @Success 200 {object} map[string]string "Collaborator removed successfully"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "Project Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects/{project_id}/collaborators [delete]
 func RemoveCollaboratorController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     ownerID := c.MustGet("user_id").(uint)
+    project := currentProject(c)
 
-    projectIDStr := c.Param("project_id")
-    projectID, err := strconv.ParseUint(projectIDStr, 10, 64)
-
-    if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
-        return
-    }
-
-    var input struct {
-        UserID uint `json:"user_id" binding:"required"`
-    }
+    var input CollaboratorInput
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(utils.Validation("invalid_request", err.Error()))
         return
     }
 
 	// Memanggil service untuk remove collaborator
-    if err := services.RemoveCollaboratorService(db, uint(projectID), input.UserID, ownerID); err != nil {
-        c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+    if err := services.RemoveCollaboratorService(db, project, input.UserID, ownerID); err != nil {
+        c.Error(err)
         return
     }
 
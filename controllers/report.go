@@ -0,0 +1,142 @@
+package controllers
+
+import (
+	"PA/services"
+	"PA/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Get Burndown godoc
+// @Summary Get the burndown series of a sprint
+// @Description Sisa pekerjaan per hari dari start_date sampai end_date sprint, dihitung dari riwayat status task (bukan status saat ini). Remaining null untuk hari yang belum terjadi. Tanpa parameter sprint, sprint active yang dipakai.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param sprint query int false "Sprint ID, default sprint active"
+// @Param unit query string false "Satuan pekerjaan" Enums(points, tasks, hours) default(points)
+// @Success 200 {object} models.Burndown "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project or sprint Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/reports/burndown [get]
+func GetBurndownController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	var sprintID uint
+	if value := c.Query("sprint"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			c.Error(utils.Validation("invalid_sprint", "sprint harus berupa id sprint"))
+			return
+		}
+		sprintID = uint(parsed)
+	}
+
+	burndown, err := services.GetBurndownService(db, currentProject(c), userID, sprintID, c.Query("unit"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": burndown})
+}
+
+// Get Cumulative Flow godoc
+// @Summary Get the cumulative flow series of a project
+// @Description Jumlah task per status pada akhir setiap hari (UTC), dihitung dari riwayat status task. Default 30 hari terakhir, maksimal 366 hari.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param from query string false "Hari pertama (YYYY-MM-DD)"
+// @Param to query string false "Hari terakhir (YYYY-MM-DD), default hari ini"
+// @Success 200 {object} models.CumulativeFlow "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/reports/cfd [get]
+func GetCumulativeFlowController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	to := time.Now().UTC()
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.Error(utils.Validation("invalid_to", "to harus berformat YYYY-MM-DD"))
+			return
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -29)
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.Error(utils.Validation("invalid_from", "from harus berformat YYYY-MM-DD"))
+			return
+		}
+		from = parsed
+	}
+
+	flow, err := services.GetCumulativeFlowService(db, currentProject(c), userID, from, to)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": flow})
+}
+
+// Get Flow Report godoc
+// @Summary Get lead time and cycle time analytics of a project
+// @Description Lead time (task dibuat sampai done) dan cycle time (pertama kali in progress sampai done) dalam jam untuk task yang selesai di antara from dan to, dengan rata-rata dan persentil p50/p85/p95, secara keseluruhan, per assignee dan per label. Kelompok diurutkan dari median cycle time terlama. Default 90 hari terakhir.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param from query string false "Task yang selesai sejak tanggal ini (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Task yang selesai sebelum tanggal ini, tanggal saja dihitung sampai akhir hari"
+// @Success 200 {object} models.FlowReport "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/reports/cycle-time [get]
+func GetFlowReportController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	timeRange, err := parseTimeRange(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	to := time.Now().UTC()
+	if timeRange.To != nil {
+		to = *timeRange.To
+	}
+	from := to.AddDate(0, 0, -90)
+	if timeRange.From != nil {
+		from = *timeRange.From
+	}
+
+	report, err := services.GetFlowReportService(db, currentProject(c), userID, from, to)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"PA/services"
+	"PA/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Get Project Schedule godoc
+// @Summary Get the critical path schedule of a project
+// @Description Menghitung earliest/latest start dan finish, slack, serta critical path dari duration_days task dan dependency (finish-to-start) di dalam project. Task tanpa estimasi dihitung default_duration_days (1 hari) dan ditandai estimate_missing. Tanggal finish bersifat eksklusif.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param start query string false "Tanggal mulai schedule (YYYY-MM-DD), default hari ini"
+// @Success 200 {object} models.Schedule "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/schedule [get]
+func GetProjectScheduleController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	start := time.Now().UTC()
+	if value := c.Query("start"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.Error(utils.Validation("invalid_start", "start harus berformat YYYY-MM-DD"))
+			return
+		}
+		start = parsed
+	}
+
+	schedule, err := services.GetProjectScheduleService(db, currentProject(c), userID, start)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": schedule})
+}
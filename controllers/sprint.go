@@ -0,0 +1,378 @@
+package controllers
+
+import (
+	"PA/models"
+	"PA/services"
+	"PA/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SprintInput digunakan untuk membuat dan mengubah sprint. Tanggal berformat YYYY-MM-DD.
+type SprintInput struct {
+	Name      string `json:"name" binding:"required" example:"Sprint 12"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"start_date" binding:"required" example:"2025-01-06"`
+	EndDate   string `json:"end_date" binding:"required" example:"2025-01-20"`
+}
+
+func (input SprintInput) sprint() (models.Sprint, error) {
+	start, err := time.Parse("2006-01-02", input.StartDate)
+	if err != nil {
+		return models.Sprint{}, utils.Validation("invalid_start_date", "start_date harus berformat YYYY-MM-DD")
+	}
+	end, err := time.Parse("2006-01-02", input.EndDate)
+	if err != nil {
+		return models.Sprint{}, utils.Validation("invalid_end_date", "end_date harus berformat YYYY-MM-DD")
+	}
+	return models.Sprint{Name: input.Name, Goal: input.Goal, StartDate: start, EndDate: end}, nil
+}
+
+// CloseSprintInput menentukan ke mana task yang belum selesai dipindah, next_sprint_id kosong berarti backlog
+type CloseSprintInput struct {
+	NextSprintID *uint `json:"next_sprint_id"`
+}
+
+// SprintTasksInput digunakan untuk memasukkan task ke sprint
+type SprintTasksInput struct {
+	TaskIDs []uint `json:"task_ids" binding:"required,min=1"`
+}
+
+// Get Sprints godoc
+// @Summary Get all sprints of a project
+// @Tags Sprints
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Success 200 {array} models.Sprint "OK"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints [get]
+func GetSprintsController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	sprints, err := services.GetSprintsService(db, currentProject(c), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sprints})
+}
+
+// Get Sprint godoc
+// @Summary Get a sprint with its summary
+// @Description Ringkasan sprint planned/active dihitung dari task saat ini. Sprint closed menyertakan snapshot task saat ditutup (kecuali untuk guest).
+// @Tags Sprints
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param sprint_id path int true "Sprint ID"
+// @Success 200 {object} models.Sprint "OK"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Sprint Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints/{sprint_id} [get]
+func GetSprintController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	sprintID, err := parseIDParam(c, "sprint_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sprint, err := services.GetSprintService(db, currentProject(c), sprintID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sprint})
+}
+
+// Add Sprint godoc
+// @Summary Create a planned sprint
+// @Tags Sprints
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param input body SprintInput true "Sprint"
+// @Success 201 {object} models.Sprint "Sprint created"
+// @Failure 400 {object} utils.Problem "Invalid sprint"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints [post]
+func AddSprintController(c *gin.Context) {
+	var input SprintInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+	sprint, err := input.sprint()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	if err := services.CreateSprintService(db, currentProject(c), &sprint, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": sprint})
+}
+
+// Update Sprint godoc
+// @Summary Update a sprint that is not closed
+// @Tags Sprints
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param sprint_id path int true "Sprint ID"
+// @Param input body SprintInput true "Sprint"
+// @Success 200 {object} models.Sprint "Sprint updated"
+// @Failure 400 {object} utils.Problem "Invalid sprint"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Sprint Not Found"
+// @Failure 409 {object} utils.Problem "Sprint closed"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints/{sprint_id} [put]
+func UpdateSprintController(c *gin.Context) {
+	var input SprintInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+	changes, err := input.sprint()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	sprintID, err := parseIDParam(c, "sprint_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sprint, err := services.UpdateSprintService(db, currentProject(c), sprintID, changes, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sprint})
+}
+
+// Delete Sprint godoc
+// @Summary Delete a planned sprint
+// @Description Task di dalam sprint dikembalikan ke backlog.
+// @Tags Sprints
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param sprint_id path int true "Sprint ID"
+// @Success 200 {object} map[string]string "Sprint deleted"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Sprint Not Found"
+// @Failure 409 {object} utils.Problem "Sprint is not planned"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints/{sprint_id} [delete]
+func DeleteSprintController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	sprintID, err := parseIDParam(c, "sprint_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.DeleteSprintService(db, currentProject(c), sprintID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Sprint deleted successfully"})
+}
+
+// Start Sprint godoc
+// @Summary Start a planned sprint
+// @Description Project hanya boleh memiliki satu sprint active.
+// @Tags Sprints
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param sprint_id path int true "Sprint ID"
+// @Success 200 {object} models.Sprint "Sprint started"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Sprint Not Found"
+// @Failure 409 {object} utils.Problem "Sprint is not planned or another sprint is active"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints/{sprint_id}/start [post]
+func StartSprintController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	sprintID, err := parseIDParam(c, "sprint_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sprint, err := services.StartSprintService(db, currentProject(c), sprintID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sprint})
+}
+
+// Close Sprint godoc
+// @Summary Close the active sprint
+// @Description Task yang belum berkategori done dipindah ke next_sprint_id atau ke backlog jika kosong. Keadaan seluruh task sprint disimpan sebagai snapshot.
+// @Tags Sprints
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param sprint_id path int true "Sprint ID"
+// @Param input body CloseSprintInput false "Sprint tujuan task yang belum selesai"
+// @Success 200 {object} models.Sprint "Sprint closed"
+// @Failure 400 {object} utils.Problem "Invalid next sprint"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Sprint Not Found"
+// @Failure 409 {object} utils.Problem "Sprint is not active"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints/{sprint_id}/close [post]
+func CloseSprintController(c *gin.Context) {
+	var input CloseSprintInput
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.Error(utils.Validation("invalid_request", err.Error()))
+			return
+		}
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	sprintID, err := parseIDParam(c, "sprint_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sprint, err := services.CloseSprintService(db, currentProject(c), sprintID, input.NextSprintID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sprint})
+}
+
+// Add Sprint Tasks godoc
+// @Summary Add tasks to a sprint
+// @Description Task dipindah dari backlog atau sprint lain yang belum ditutup. Gunakan GET /api/projects/{project_id}/tasks?sprint={sprint_id} untuk melihat task sprint.
+// @Tags Sprints
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param sprint_id path int true "Sprint ID"
+// @Param input body SprintTasksInput true "Task"
+// @Success 200 {object} models.Sprint "Tasks added"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Sprint or task Not Found"
+// @Failure 409 {object} utils.Problem "Sprint closed"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints/{sprint_id}/tasks [post]
+func AddSprintTasksController(c *gin.Context) {
+	var input SprintTasksInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	sprintID, err := parseIDParam(c, "sprint_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	sprint, err := services.AddSprintTasksService(db, currentProject(c), sprintID, input.TaskIDs, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": sprint})
+}
+
+// Remove Sprint Task godoc
+// @Summary Move a task from a sprint back to the backlog
+// @Tags Sprints
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param sprint_id path int true "Sprint ID"
+// @Param task_id path int true "Task ID"
+// @Success 200 {object} map[string]string "Task removed"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Sprint or task Not Found"
+// @Failure 409 {object} utils.Problem "Sprint closed"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/sprints/{sprint_id}/tasks/{task_id} [delete]
+func RemoveSprintTaskController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	sprintID, err := parseIDParam(c, "sprint_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	taskID, err := parseIDParam(c, "task_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.RemoveSprintTaskService(db, currentProject(c), sprintID, taskID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task removed from sprint"})
+}
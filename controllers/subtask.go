@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"PA/services"
+	"PA/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// ParentInput digunakan untuk memindahkan task. parent_id null menjadikan task sebagai task utama.
+type ParentInput struct {
+	ParentID *uint `json:"parent_id"`
+}
+
+// ChecklistItemInput digunakan untuk menambah item checklist
+type ChecklistItemInput struct {
+	Title string `json:"title" binding:"required"`
+}
+
+// ChecklistItemPatch digunakan untuk mengubah item checklist, field yang tidak dikirim tidak diubah
+type ChecklistItemPatch struct {
+	Title *string `json:"title"`
+	Done  *bool   `json:"done"`
+}
+
+// Set Task Parent godoc
+// @Summary Move a task under another task (subtask)
+// @Description Parent harus berada di project yang sama, tidak boleh membentuk siklus, dan kedalaman maksimum 3 level.
+// @Tags Subtasks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
+// @Param input body ParentInput true "Parent task"
+// @Success 200 {object} models.Task "Parent updated"
+// @Header 200 {string} ETag "Version task"
+// @Failure 400 {object} utils.Problem "Invalid parent"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/parent [put]
+func SetTaskParentController(c *gin.Context) {
+	var input ParentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	task, err := services.SetTaskParentService(db, taskID, input.ParentID, expectedVersion, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, gin.H{"data": task})
+}
+
+// Add Checklist Item godoc
+// @Summary Add a checklist item to a task
+// @Tags Subtasks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param input body ChecklistItemInput true "Checklist item"
+// @Success 201 {object} models.ChecklistItem "Checklist item created"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/checklist [post]
+func AddChecklistItemController(c *gin.Context) {
+	var input ChecklistItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	item, err := services.AddChecklistItemService(db, taskID, input.Title, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// Update Checklist Item godoc
+// @Summary Rename or check/uncheck a checklist item
+// @Tags Subtasks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param item_id path int true "Checklist Item ID"
+// @Param input body ChecklistItemPatch true "Perubahan item"
+// @Success 200 {object} models.ChecklistItem "Checklist item updated"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Checklist Item Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/checklist/{item_id} [patch]
+func UpdateChecklistItemController(c *gin.Context) {
+	var input ChecklistItemPatch
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	itemID, err := parseIDParam(c, "item_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	item, err := services.UpdateChecklistItemService(db, taskID, itemID, input.Title, input.Done, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// Delete Checklist Item godoc
+// @Summary Delete a checklist item
+// @Tags Subtasks
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param item_id path int true "Checklist Item ID"
+// @Success 200 {object} map[string]string "Checklist item deleted successfully"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Checklist Item Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/checklist/{item_id} [delete]
+func DeleteChecklistItemController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	itemID, err := parseIDParam(c, "item_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.DeleteChecklistItemService(db, taskID, itemID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item deleted successfully"})
+}
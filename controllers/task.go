@@ -1,22 +1,29 @@
 package controllers
 
 import (
+    "encoding/json"
+    "io"
     "net/http"
     "strconv"
     "time"
     "PA/models"
     "PA/services"
+    "PA/utils"
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
 )
 
 // taskInput digunakan untuk validasi input add & edit task
 type taskInput struct {
-    Title       string   `json:"title"`
-    Description string   `json:"description"`
-    Status      string   `json:"status"`
-    AssignedTo  []uint   `json:"assigned_to"`
-    Deadline    string   `json:"deadline"`
+    Title          string   `json:"title"`
+    Description    string   `json:"description"`
+    Status         string   `json:"status"` // nama status dari workflow project, kosong = status awal
+    AssignedTo     []uint   `json:"assigned_to"`
+    Deadline       string   `json:"deadline"`
+    DurationDays   *int     `json:"duration_days"` // estimasi durasi dalam hari untuk schedule
+    StoryPoints    *int     `json:"story_points"`
+    EstimatedHours *float64 `json:"estimated_hours"` // estimasi dalam jam
+    ParentID       *uint    `json:"parent_id"` // hanya dipakai saat membuat task, gunakan PUT /api/tasks/{id}/parent untuk memindahkan
 }
 
 // digunakan untuk parse input deadline
@@ -24,6 +31,15 @@ func parseDeadline(deadline string) (time.Time, error) {
     return time.Parse("2006-01-02 15:04:05", deadline)
 }
 
+// digunakan untuk parse parameter id pada url
+func parseIDParam(c *gin.Context, name string) (uint, error) {
+    id, err := strconv.ParseUint(c.Param(name), 10, 64)
+    if err != nil {
+        return 0, utils.Validation("invalid_"+name, "Invalid "+name)
+    }
+    return uint(id), nil
+}
+
 // Get All Tasks godoc
 // @Summary Get all tasks assigned to the user
 // @Tags Tasks
@@ -31,22 +47,42 @@ func parseDeadline(deadline string) (time.Time, error) {
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer Token"
-// @Success 200 {array} models.Task "OK"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Param limit query int false "Jumlah item per halaman (default 20, max 100)"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya"
+// @Param sort query string false "deadline, created atau title. Awali dengan - untuk descending" Enums(deadline, -deadline, created, -created, title, -title)
+// @Param status query string false "Filter nama status"
+// @Param assignee query int false "Filter id user yang di-assign"
+// @Param deadline_before query string false "Deadline sebelum (YYYY-MM-DD atau YYYY-MM-DD HH:MM:SS)"
+// @Param deadline_after query string false "Deadline setelah (YYYY-MM-DD atau YYYY-MM-DD HH:MM:SS)"
+// @Param q query string false "Cari di title dan description"
+// @Param sprint query string false "Filter id sprint, atau backlog untuk task yang belum masuk sprint"
+// @Success 200 {object} TaskListResponse "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/tasks [get]
 func GetAllTaskController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     userID, _ := c.Get("user_id")
 
-    tasks, err := services.GetAllTasksService(db, userID.(uint))
+    opts, err := parseListOptions(c)
     if err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+        c.Error(err)
+        return
+    }
+    filter, err := parseTaskFilter(c)
+    if err != nil {
+        c.Error(err)
         return
     }
 
-    c.JSON(http.StatusOK, tasks)
+    tasks, next, err := services.GetAllTasksService(db, userID.(uint), filter, opts)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    c.JSON(http.StatusOK, TaskListResponse{Data: tasks, NextCursor: next})
 }
 
 // Get Task by ID godoc
@@ -58,22 +94,29 @@ func GetAllTaskController(c *gin.Context) {
 // @Param Authorization header string true "Bearer Token"
 // @Param id path int true "Task ID"
 // @Success 200 {object} models.Task "OK"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "Task Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Header 200 {string} ETag "Version task"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/tasks/{id} [get]
 func GetTaskByIDController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     userID, _ := c.Get("user_id")
 
-    taskID, _ := strconv.Atoi(c.Param("id"))
-    task, err := services.GetTaskByIDService(db, uint(taskID), userID.(uint))
+    taskID, err := parseIDParam(c, "id")
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    task, err := services.GetTaskByIDService(db, taskID, userID.(uint))
     if err != nil {
-        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+        c.Error(err)
         return
     }
 
+    setETag(c, task.Version)
     c.JSON(http.StatusOK, task)
 }
 
@@ -85,24 +128,44 @@ func GetTaskByIDController(c *gin.Context) {
 // @Produce json
 // @Param Authorization header string true "Bearer Token"
 // @Param project_id path int true "Project ID"
-// @Success 200 {array} models.Task "OK"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "Project Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Param limit query int false "Jumlah item per halaman (default 20, max 100)"
+// @Param cursor query string false "next_cursor dari halaman sebelumnya"
+// @Param sort query string false "deadline, created atau title. Awali dengan - untuk descending" Enums(deadline, -deadline, created, -created, title, -title)
+// @Param status query string false "Filter nama status"
+// @Param assignee query int false "Filter id user yang di-assign"
+// @Param deadline_before query string false "Deadline sebelum (YYYY-MM-DD atau YYYY-MM-DD HH:MM:SS)"
+// @Param deadline_after query string false "Deadline setelah (YYYY-MM-DD atau YYYY-MM-DD HH:MM:SS)"
+// @Param q query string false "Cari di title dan description"
+// @Param sprint query string false "Filter id sprint, atau backlog untuk task yang belum masuk sprint"
+// @Success 200 {object} TaskListResponse "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects/{project_id}/tasks [get]
 func GetTaskByProjectController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     userID, _ := c.Get("user_id")
 
-    projectID, _ := strconv.Atoi(c.Param("project_id"))
-    tasks, err := services.GetTaskByProjectService(db, uint(projectID), userID.(uint))
+    opts, err := parseListOptions(c)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    filter, err := parseTaskFilter(c)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    tasks, next, err := services.GetTaskByProjectService(db, currentProject(c), userID.(uint), filter, opts)
     if err != nil {
-        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+        c.Error(err)
         return
     }
 
-    c.JSON(http.StatusOK, tasks)
+    c.JSON(http.StatusOK, TaskListResponse{Data: tasks, NextCursor: next})
 }
 
 // Add Task godoc
@@ -115,46 +178,55 @@ func GetTaskByProjectController(c *gin.Context) {
 // @Param project_id path int true "Project ID"
 // @Param input body taskInput true "Task Data"
 // @Success 201 {object} models.Task "Task created successfully"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Header 201 {string} ETag "Version task"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects/{project_id}/tasks [post]
 func AddTaskController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     
-    projectID, _ := strconv.Atoi(c.Param("project_id"))
     var input taskInput
     
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(utils.Validation("invalid_request", err.Error()))
         return
     }
 
     deadline, err := parseDeadline(input.Deadline)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid deadline format"})
+        c.Error(utils.Validation("invalid_deadline", "Invalid deadline format"))
         return
     }
 
     task := models.Task{
-        Title:       input.Title,
-        Description: input.Description,
-        Status:      input.Status,
-        Deadline:    deadline,
+        Title:          input.Title,
+        Description:    input.Description,
+        Status:         input.Status,
+        Deadline:       deadline,
+        ParentID:       input.ParentID,
+        StoryPoints:    input.StoryPoints,
+        EstimatedHours: input.EstimatedHours,
+    }
+    if input.DurationDays != nil {
+        task.DurationDays = *input.DurationDays
     }
 
     userID := c.MustGet("user_id").(uint)
     
-    if err := services.CreateTaskService(db, uint(projectID), &task, input.AssignedTo, userID); err != nil {
-        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+    if err := services.CreateTaskService(db, currentProject(c), &task, input.AssignedTo, userID); err != nil {
+        c.Error(err)
         return
     }
 
+    setETag(c, task.Version)
     c.JSON(http.StatusCreated, gin.H{"data": task})
 }
 
 // Update Task godoc
 // @Summary Update an existing task
+// @Description Mengganti title, description, status dan deadline. Jika assigned_to tidak dikirim, assignee tidak diubah.
 // @Tags Tasks
 // @Security BearerAuth
 // @Accept json
@@ -162,70 +234,358 @@ func AddTaskController(c *gin.Context) {
 // @Param Authorization header string true "Bearer Token"
 // @Param project_id path int true "Project ID"
 // @Param task_id path int true "Task ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
 // @Param input body taskInput true "Task Data"
 // @Success 200 {object} models.Task "Task updated successfully"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "Task Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Header 200 {string} ETag "Version task"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 409 {object} utils.Problem "Illegal status transition or task still blocked"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/projects/{project_id}/tasks/{task_id} [put]
 func UpdateTaskController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     userID := c.MustGet("user_id").(uint)
     
-    projectID, _ := strconv.Atoi(c.Param("project_id"))
-    taskID, _ := strconv.Atoi(c.Param("task_id"))
+    taskID, err := parseIDParam(c, "task_id")
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    expectedVersion, err := ifMatchVersion(c)
+    if err != nil {
+        c.Error(err)
+        return
+    }
     
     var input taskInput
     if err := c.ShouldBindJSON(&input); err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+        c.Error(utils.Validation("invalid_request", err.Error()))
         return
     }
 
     deadline, err := parseDeadline(input.Deadline)
     if err != nil {
-        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid deadline format"})
+        c.Error(utils.Validation("invalid_deadline", "Invalid deadline format"))
         return
     }
 
-    task := models.Task{
-        Title:       input.Title,
-        Description: input.Description,
-        Status:      input.Status,
-        Deadline:    deadline,
+    // assigned_to yang tidak dikirim berarti assignee tidak diubah, [] untuk menghapus semua assignee
+    patch := models.TaskPatch{
+        Title:       &input.Title,
+        Description: &input.Description,
+        Status:      &input.Status,
+        Deadline:    &deadline,
+    }
+    if input.AssignedTo != nil {
+        patch.AssignedTo = &input.AssignedTo
+    }
+    if input.DurationDays != nil {
+        patch.DurationDays = input.DurationDays
+    }
+    if input.StoryPoints != nil {
+        patch.StoryPoints = &input.StoryPoints
+    }
+    if input.EstimatedHours != nil {
+        patch.EstimatedHours = &input.EstimatedHours
+    }
+
+    task, err := services.UpdateTaskService(db, currentProject(c), taskID, patch, expectedVersion, userID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    setETag(c, task.Version)
+    c.JSON(http.StatusOK, gin.H{"data": task})
+}
+
+// Patch Task godoc
+// @Summary Partially update a task
+// @Description Menggunakan semantik JSON Merge Patch (RFC 7396): hanya field yang dikirim yang diubah, null menghapus nilai field.
+// @Description assigned_to menggantikan seluruh assignee, gunakan endpoint assignees untuk menambah/menghapus satu per satu.
+// @Tags Tasks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param task_id path int true "Task ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
+// @Param input body taskInput true "Field yang diubah"
+// @Success 200 {object} models.Task "Task updated successfully"
+// @Header 200 {string} ETag "Version task"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 409 {object} utils.Problem "Illegal status transition or task still blocked"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/tasks/{task_id} [patch]
+func PatchTaskController(c *gin.Context) {
+    db := c.MustGet("db").(*gorm.DB)
+    userID := c.MustGet("user_id").(uint)
+
+    taskID, err := parseIDParam(c, "task_id")
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    expectedVersion, err := ifMatchVersion(c)
+    if err != nil {
+        c.Error(err)
+        return
     }
 
-    if err := services.UpdateTaskService(db, uint(projectID), uint(taskID), &task, input.AssignedTo, userID); err != nil {
-        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+    body, err := io.ReadAll(c.Request.Body)
+    if err != nil {
+        c.Error(utils.Validation("invalid_request", err.Error()))
         return
     }
 
+    patch, err := parseTaskPatch(body)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    task, err := services.UpdateTaskService(db, currentProject(c), taskID, patch, expectedVersion, userID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    setETag(c, task.Version)
+    c.JSON(http.StatusOK, gin.H{"data": task})
+}
+
+// parseTaskPatch membaca body merge patch. null berarti field dikosongkan.
+func parseTaskPatch(body []byte) (models.TaskPatch, error) {
+    var fields map[string]json.RawMessage
+    if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+        return models.TaskPatch{}, utils.Validation("invalid_request", "body harus berupa JSON object")
+    }
+
+    var patch models.TaskPatch
+    for key, raw := range fields {
+        isNull := string(raw) == "null"
+        switch key {
+        case "title", "description", "status":
+            value := ""
+            if !isNull {
+                if err := json.Unmarshal(raw, &value); err != nil {
+                    return models.TaskPatch{}, utils.Validation("invalid_"+key, key+" harus berupa string")
+                }
+            }
+            switch key {
+            case "title":
+                patch.Title = &value
+            case "description":
+                patch.Description = &value
+            case "status":
+                if isNull {
+                    return models.TaskPatch{}, utils.Validation("invalid_status", "status tidak boleh null")
+                }
+                patch.Status = &value
+            }
+        case "deadline":
+            deadline := time.Time{}
+            if !isNull {
+                var value string
+                if err := json.Unmarshal(raw, &value); err != nil {
+                    return models.TaskPatch{}, utils.Validation("invalid_deadline", "Invalid deadline format")
+                }
+                parsed, err := parseDeadline(value)
+                if err != nil {
+                    return models.TaskPatch{}, utils.Validation("invalid_deadline", "Invalid deadline format")
+                }
+                deadline = parsed
+            }
+            patch.Deadline = &deadline
+        case "duration_days":
+            days := 0
+            if !isNull {
+                if err := json.Unmarshal(raw, &days); err != nil {
+                    return models.TaskPatch{}, utils.Validation("invalid_duration_days", "duration_days harus berupa angka")
+                }
+            }
+            patch.DurationDays = &days
+        case "story_points":
+            var points *int
+            if err := json.Unmarshal(raw, &points); err != nil {
+                return models.TaskPatch{}, utils.Validation("invalid_story_points", "story_points harus berupa angka bulat")
+            }
+            patch.StoryPoints = &points
+        case "estimated_hours":
+            var hours *float64
+            if err := json.Unmarshal(raw, &hours); err != nil {
+                return models.TaskPatch{}, utils.Validation("invalid_estimated_hours", "estimated_hours harus berupa angka")
+            }
+            patch.EstimatedHours = &hours
+        case "assigned_to":
+            userIDs := []uint{}
+            if !isNull {
+                if err := json.Unmarshal(raw, &userIDs); err != nil {
+                    return models.TaskPatch{}, utils.Validation("invalid_assigned_to", "assigned_to harus berupa array id user")
+                }
+            }
+            patch.AssignedTo = &userIDs
+        default:
+            return models.TaskPatch{}, utils.Validation("unknown_field", "field tidak dikenal: "+key)
+        }
+    }
+    return patch, nil
+}
+
+// assigneesInput digunakan untuk menambah assignee task
+type assigneesInput struct {
+    UserIDs []uint `json:"user_ids" binding:"required,min=1"`
+}
+
+// Add Task Assignees godoc
+// @Summary Assign users to a task
+// @Description Menambahkan assignee tanpa mengubah assignee yang sudah ada.
+// @Tags Tasks
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param task_id path int true "Task ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
+// @Param input body assigneesInput true "User IDs"
+// @Success 200 {object} models.Task "Assignees added"
+// @Header 200 {string} ETag "Version task"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/tasks/{task_id}/assignees [post]
+func AddTaskAssigneesController(c *gin.Context) {
+    db := c.MustGet("db").(*gorm.DB)
+    userID := c.MustGet("user_id").(uint)
+
+    taskID, err := parseIDParam(c, "task_id")
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    expectedVersion, err := ifMatchVersion(c)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    var input assigneesInput
+    if err := c.ShouldBindJSON(&input); err != nil {
+        c.Error(utils.Validation("invalid_request", err.Error()))
+        return
+    }
+
+    task, err := services.AddTaskAssigneesService(db, currentProject(c), taskID, input.UserIDs, expectedVersion, userID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    setETag(c, task.Version)
+    c.JSON(http.StatusOK, gin.H{"data": task})
+}
+
+// Remove Task Assignee godoc
+// @Summary Unassign a user from a task
+// @Tags Tasks
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param task_id path int true "Task ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} models.Task "Assignee removed"
+// @Header 200 {string} ETag "Version task"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task or Assignee Not Found"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/tasks/{task_id}/assignees/{user_id} [delete]
+func RemoveTaskAssigneeController(c *gin.Context) {
+    db := c.MustGet("db").(*gorm.DB)
+    userID := c.MustGet("user_id").(uint)
+
+    taskID, err := parseIDParam(c, "task_id")
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    expectedVersion, err := ifMatchVersion(c)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    assigneeID, err := parseIDParam(c, "user_id")
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    task, err := services.RemoveTaskAssigneeService(db, currentProject(c), taskID, assigneeID, expectedVersion, userID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+
+    setETag(c, task.Version)
     c.JSON(http.StatusOK, gin.H{"data": task})
 }
 
 // Delete Task godoc
 // @Summary Delete a task by ID
+// @Description Task beserta subtask-nya dipindahkan ke trash dan bisa dipulihkan lewat /api/trash/tasks/{id}/restore
 // @Tags Tasks
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer Token"
 // @Param id path int true "Task ID"
+// @Param If-Match header string true "ETag dari response sebelumnya untuk optimistic locking, atau * untuk menimpa tanpa cek version"
 // @Success 200 {object} map[string]string "Task deleted successfully"
-// @Failure 400 {object} map[string]string "Bad Request"
-// @Failure 401 {object} map[string]string "Unauthorized"
-// @Failure 404 {object} map[string]string "Task Not Found"
-// @Failure 500 {object} map[string]string "Internal Server Error"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 412 {object} utils.Problem "Version has changed (If-Match mismatch)"
+// @Failure 428 {object} utils.Problem "If-Match header is required"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
 // @Router /api/tasks/{id} [delete]
 func DeleteTaskController(c *gin.Context) {
     db := c.MustGet("db").(*gorm.DB)
     userID := c.MustGet("user_id").(uint)
-    taskID, _ := strconv.Atoi(c.Param("id"))
+    taskID, err := parseIDParam(c, "id")
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    expectedVersion, err := ifMatchVersion(c)
+    if err != nil {
+        c.Error(err)
+        return
+    }
 
-    if err := services.DeleteTaskService(db, uint(taskID), userID); err != nil {
-        c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+    if err := services.DeleteTaskService(db, taskID, expectedVersion, userID); err != nil {
+        c.Error(err)
         return
     }
 
     c.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
-}
\ No newline at end of file
+}
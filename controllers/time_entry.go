@@ -0,0 +1,390 @@
+package controllers
+
+import (
+	"PA/models"
+	"PA/services"
+	"PA/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// TimerInput digunakan saat memulai timer, note bersifat opsional
+type TimerInput struct {
+	Note string `json:"note"`
+}
+
+// TimeEntryInput digunakan untuk mencatat waktu secara manual. Isi ended_at atau duration_minutes.
+type TimeEntryInput struct {
+	StartedAt       time.Time  `json:"started_at" binding:"required" example:"2025-01-20T09:00:00Z"`
+	EndedAt         *time.Time `json:"ended_at" example:"2025-01-20T11:30:00Z"`
+	DurationMinutes int        `json:"duration_minutes" example:"150"`
+	Note            string     `json:"note"`
+}
+
+func (input TimeEntryInput) entry() models.TimeEntry {
+	return models.TimeEntry{
+		StartedAt:       input.StartedAt,
+		EndedAt:         input.EndedAt,
+		DurationSeconds: int64(input.DurationMinutes) * 60,
+		Note:            input.Note,
+	}
+}
+
+// TaskTimeResponse berisi time entry task beserta totalnya
+type TaskTimeResponse struct {
+	Data    []models.TimeEntry `json:"data"`
+	Summary models.TimeSummary `json:"summary"`
+}
+
+// parseTimeRange membaca from/to dari query string. to berupa tanggal saja dihitung sampai akhir hari tersebut.
+func parseTimeRange(c *gin.Context) (models.TimeRange, error) {
+	var timeRange models.TimeRange
+	for name, target := range map[string]**time.Time{
+		"from": &timeRange.From,
+		"to":   &timeRange.To,
+	} {
+		value := c.Query(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := parseDateParam(value)
+		if err != nil {
+			return timeRange, utils.Validation("invalid_"+name, name+" harus berformat YYYY-MM-DD atau RFC3339")
+		}
+		if name == "to" && len(value) == len("2006-01-02") {
+			parsed = parsed.AddDate(0, 0, 1)
+		}
+		*target = &parsed
+	}
+	return timeRange, nil
+}
+
+// Start Timer godoc
+// @Summary Start a timer on a task
+// @Description Setiap user hanya boleh memiliki satu timer yang berjalan. Hanya assignee atau anggota project yang boleh mengubah task yang bisa mencatat waktu.
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param input body TimerInput false "Catatan"
+// @Success 201 {object} models.TimeEntry "Timer started"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 409 {object} utils.Problem "Timer already running"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/timer/start [post]
+func StartTimerController(c *gin.Context) {
+	var input TimerInput
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.Error(utils.Validation("invalid_request", err.Error()))
+			return
+		}
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry, err := services.StartTimerService(db, taskID, input.Note, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": entry})
+}
+
+// Stop Timer godoc
+// @Summary Stop the running timer on a task
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Success 200 {object} models.TimeEntry "Timer stopped"
+// @Failure 409 {object} utils.Problem "No running timer"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/timer/stop [post]
+func StopTimerController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry, err := services.StopTimerService(db, taskID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entry})
+}
+
+// Get Running Timer godoc
+// @Summary Get the running timer of the current user
+// @Description data bernilai null jika tidak ada timer yang berjalan.
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {object} models.TimeEntry "OK"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/me/timer [get]
+func GetRunningTimerController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	entry, err := services.GetRunningTimerService(db, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entry})
+}
+
+// Get Task Time godoc
+// @Summary Get time entries and totals of a task
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param from query string false "Entry yang dimulai sejak tanggal ini (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Entry yang dimulai sebelum tanggal ini, tanggal saja dihitung sampai akhir hari"
+// @Success 200 {object} TaskTimeResponse "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/time-entries [get]
+func GetTaskTimeEntriesController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	timeRange, err := parseTimeRange(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entries, summary, err := services.GetTaskTimeService(db, taskID, userID, timeRange)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TaskTimeResponse{Data: entries, Summary: summary})
+}
+
+// Add Time Entry godoc
+// @Summary Log time manually on a task
+// @Description Isi started_at dan ended_at, atau started_at dan duration_minutes.
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param input body TimeEntryInput true "Time entry"
+// @Success 201 {object} models.TimeEntry "Time entry created"
+// @Failure 400 {object} utils.Problem "Invalid time entry"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/time-entries [post]
+func AddTimeEntryController(c *gin.Context) {
+	var input TimeEntryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry, err := services.AddTimeEntryService(db, taskID, input.entry(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"data": entry})
+}
+
+// Update Time Entry godoc
+// @Summary Update an own time entry
+// @Description Hanya entry milik sendiri yang sudah selesai (bukan timer yang sedang berjalan) yang dapat diubah.
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param entry_id path int true "Time entry ID"
+// @Param input body TimeEntryInput true "Time entry"
+// @Success 200 {object} models.TimeEntry "Time entry updated"
+// @Failure 400 {object} utils.Problem "Invalid time entry"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Time entry Not Found"
+// @Failure 409 {object} utils.Problem "Timer still running"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/time-entries/{entry_id} [put]
+func UpdateTimeEntryController(c *gin.Context) {
+	var input TimeEntryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	entryID, err := parseIDParam(c, "entry_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry, err := services.UpdateTimeEntryService(db, taskID, entryID, input.entry(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": entry})
+}
+
+// Delete Time Entry godoc
+// @Summary Delete a time entry
+// @Description Entry dapat dihapus oleh pemiliknya atau owner/admin project.
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Param entry_id path int true "Time entry ID"
+// @Success 200 {object} map[string]string "Time entry deleted"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Time entry Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/tasks/{id}/time-entries/{entry_id} [delete]
+func DeleteTimeEntryController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	entryID, err := parseIDParam(c, "entry_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := services.DeleteTimeEntryService(db, taskID, entryID, userID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry deleted successfully"})
+}
+
+// Get Project Time godoc
+// @Summary Get time totals of a project per user and per task
+// @Description Hanya role yang boleh melihat semua task (bukan guest). Timer yang masih berjalan tidak dihitung.
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param from query string false "Entry yang dimulai sejak tanggal ini (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Entry yang dimulai sebelum tanggal ini, tanggal saja dihitung sampai akhir hari"
+// @Success 200 {object} models.TimeSummary "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/time [get]
+func GetProjectTimeController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	timeRange, err := parseTimeRange(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	summary, err := services.GetProjectTimeService(db, currentProject(c), userID, timeRange)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": summary})
+}
+
+// Get My Time godoc
+// @Summary Get time totals of the current user per project and per task
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param from query string false "Entry yang dimulai sejak tanggal ini (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Entry yang dimulai sebelum tanggal ini, tanggal saja dihitung sampai akhir hari"
+// @Success 200 {object} models.TimeSummary "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/me/time [get]
+func GetMyTimeController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	timeRange, err := parseTimeRange(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	summary, err := services.GetMyTimeService(db, userID, timeRange)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": summary})
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"PA/export"
+	"PA/models"
+	"PA/services"
+	"PA/utils"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// parseTimesheetQuery membaca from, to, group_by, format dan tz dari query string
+func parseTimesheetQuery(c *gin.Context) (timeRange models.TimeRange, groupBy, format string, loc *time.Location, err error) {
+	timeRange, err = parseTimeRange(c)
+	if err != nil {
+		return
+	}
+
+	format = c.DefaultQuery("format", export.FormatCSV)
+	if !export.IsValidFormat(format) {
+		err = utils.Validation("invalid_format", "format harus csv atau xlsx")
+		return
+	}
+
+	loc = time.UTC
+	if tz := c.Query("tz"); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			err = utils.Validation("invalid_tz", "tz harus berupa nama zona waktu IANA, misalnya Asia/Jakarta")
+			return
+		}
+	}
+	return timeRange, c.Query("group_by"), format, loc, nil
+}
+
+// writeTimesheet menulis header download lalu men-stream timesheet. Setelah data mulai terkirim
+// error tidak bisa lagi dikembalikan sebagai problem+json, sehingga hanya dicatat ke log.
+func writeTimesheet(c *gin.Context, filter models.TimesheetFilter, format string, loc *time.Location, filename string) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename + "." + format}))
+	c.Status(http.StatusOK)
+
+	w, err := export.New(format, c.Writer)
+	if err == nil {
+		err = services.WriteTimesheetService(db, filter, loc, w)
+	}
+	if err != nil && !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Disposition")
+		c.Error(err)
+		return
+	}
+	if err != nil {
+		log.Printf("%s %s: export timesheet gagal: %v", c.Request.Method, c.Request.URL.Path, err)
+		c.Abort()
+	}
+}
+
+// Export Project Timesheet godoc
+// @Summary Export the timesheet of a project as CSV or XLSX
+// @Description Baris di-stream langsung dari database. Setiap kelompok (day, task atau user) diakhiri baris subtotal, dan file diakhiri baris total. Hanya role yang boleh melihat semua task (bukan guest).
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param from query string false "Entry yang dimulai sejak tanggal ini (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Entry yang dimulai sebelum tanggal ini, tanggal saja dihitung sampai akhir hari"
+// @Param group_by query string false "Pengelompokan baris" Enums(day, task, user) default(day)
+// @Param format query string false "Format file" Enums(csv, xlsx) default(csv)
+// @Param tz query string false "Zona waktu untuk tanggal dan jam, default UTC (contoh Asia/Jakarta)"
+// @Success 200 {file} file "Timesheet"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/timesheet [get]
+func ExportProjectTimesheetController(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+	project := currentProject(c)
+
+	timeRange, groupBy, format, loc, err := parseTimesheetQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	filter, err := services.ProjectTimesheetFilter(project, userID, timeRange, groupBy)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeTimesheet(c, filter, format, loc, "timesheet-project-"+strconv.FormatUint(uint64(project.ID), 10))
+}
+
+// Export My Timesheet godoc
+// @Summary Export the timesheet of the current user as CSV or XLSX
+// @Description Berisi waktu kerja user sendiri di semua project. Setiap kelompok (day, task atau user) diakhiri baris subtotal, dan file diakhiri baris total.
+// @Tags Time Tracking
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param Authorization header string true "Bearer Token"
+// @Param from query string false "Entry yang dimulai sejak tanggal ini (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Entry yang dimulai sebelum tanggal ini, tanggal saja dihitung sampai akhir hari"
+// @Param group_by query string false "Pengelompokan baris" Enums(day, task, user) default(day)
+// @Param format query string false "Format file" Enums(csv, xlsx) default(csv)
+// @Param tz query string false "Zona waktu untuk tanggal dan jam, default UTC (contoh Asia/Jakarta)"
+// @Success 200 {file} file "Timesheet"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/me/timesheet [get]
+func ExportMyTimesheetController(c *gin.Context) {
+	userID := c.MustGet("user_id").(uint)
+
+	timeRange, groupBy, format, loc, err := parseTimesheetQuery(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	filter, err := services.UserTimesheetFilter(userID, timeRange, groupBy)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	writeTimesheet(c, filter, format, loc, "timesheet")
+}
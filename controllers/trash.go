@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"PA/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Get Trash godoc
+// @Summary Get the trash of the current user
+// @Description Project milik user dan task yang boleh dipulihkan user (owner/admin project), terbaru dihapus dulu.
+// @Description Item dihapus permanen pada purge_at (TRASH_RETENTION_DAYS, default 30 hari setelah dihapus).
+// @Tags Trash
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Success 200 {array} models.TrashItem "OK"
+// @Failure 401 {object} utils.Problem "Unauthorized"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/trash [get]
+func GetTrashController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	items, err := services.GetTrashService(db, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": items})
+}
+
+// Restore Project godoc
+// @Summary Restore a project from the trash
+// @Description Project dipulihkan bersama seluruh task yang ikut terhapus bersamanya. Hanya owner.
+// @Tags Trash
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Success 200 {object} models.Project "Project restored"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project not found in trash"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/trash/projects/{project_id}/restore [post]
+func RestoreProjectController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	projectID, err := parseIDParam(c, "project_id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	project, err := services.RestoreProjectService(db, projectID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, project.Version)
+	c.JSON(http.StatusOK, gin.H{"data": project})
+}
+
+// Restore Task godoc
+// @Summary Restore a task from the trash
+// @Description Task dipulihkan bersama subtask yang ikut terhapus bersamanya. Project dan parent task harus sudah aktif.
+// @Tags Trash
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param id path int true "Task ID"
+// @Success 200 {object} models.Task "Task restored"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Task not found in trash"
+// @Failure 409 {object} utils.Problem "Project or parent task is still in the trash, or project is archived"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/trash/tasks/{id}/restore [post]
+func RestoreTaskController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	taskID, err := parseIDParam(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	task, err := services.RestoreTaskService(db, taskID, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, gin.H{"data": task})
+}
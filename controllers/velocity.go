@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"PA/models"
+	"PA/services"
+	"PA/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Get Project Velocity godoc
+// @Summary Get the velocity report of a project
+// @Description Menjumlahkan task, story_points dan estimated_hours dari task yang selesai (completed_at) per window. Default 6 window terakhir masing-masing 14 hari sampai akhir hari ini. Rata-rata hanya dihitung dari window yang sudah lengkap.
+// @Description Dengan by=sprint, setiap window adalah sprint closed yang berakhir di antara from dan to, dihitung dari snapshot saat sprint ditutup.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path int true "Project ID"
+// @Param by query string false "Pengelompokan" Enums(window, sprint) default(window)
+// @Param window_days query int false "Panjang window dalam hari (default 14)"
+// @Param from query string false "Awal window pertama (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Akhir report, tanggal saja dihitung sampai akhir hari (default hari ini)"
+// @Success 200 {object} models.VelocityReport "OK"
+// @Failure 400 {object} utils.Problem "Bad Request"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/velocity [get]
+func GetProjectVelocityController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	windowDays := services.DefaultVelocityWindowDays
+	if value := c.Query("window_days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.Error(utils.Validation("invalid_window_days", "window_days harus berupa angka"))
+			return
+		}
+		windowDays = parsed
+	}
+
+	timeRange, err := parseTimeRange(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	to := time.Now().UTC().Truncate(24 * time.Hour).AddDate(0, 0, 1)
+	if timeRange.To != nil {
+		to = *timeRange.To
+	}
+	from := to.AddDate(0, 0, -windowDays*services.DefaultVelocityWindows)
+	if timeRange.From != nil {
+		from = *timeRange.From
+	}
+
+	var report models.VelocityReport
+	switch c.DefaultQuery("by", models.VelocityByWindow) {
+	case models.VelocityByWindow:
+		report, err = services.GetProjectVelocityService(db, currentProject(c), userID, from, to, windowDays)
+	case models.VelocityBySprint:
+		report, err = services.GetSprintVelocityService(db, currentProject(c), userID, from, to)
+	default:
+		err = utils.Validation("invalid_by", "by harus window atau sprint")
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": report})
+}
@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"PA/models"
+	"PA/services"
+	"PA/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// WorkflowInput digunakan untuk validasi input update workflow. Urutan statuses menentukan position.
+type WorkflowInput struct {
+	Statuses []WorkflowStatusInput `json:"statuses" binding:"required,min=1,dive"`
+	Transitions []WorkflowTransitionInput `json:"transitions" binding:"dive"`
+}
+
+// WorkflowStatusInput, isi id untuk mempertahankan (atau rename) status yang sudah ada
+type WorkflowStatusInput struct {
+	ID uint `json:"id"`
+	Name string `json:"name" binding:"required"`
+	Category string `json:"category" binding:"required" enums:"todo,in_progress,done"`
+}
+
+type WorkflowTransitionInput struct {
+	From string `json:"from" binding:"required"`
+	To string `json:"to" binding:"required"`
+}
+
+// Get Workflow godoc
+// @Summary Get the task workflow of a project
+// @Tags Workflow
+// @Security BearerAuth
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path uint true "Project ID"
+// @Success 200 {object} models.Workflow "Success"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/workflow [get]
+func GetWorkflowController(c *gin.Context) {
+	db := c.MustGet("db").(*gorm.DB)
+
+	workflow, err := services.GetWorkflowService(db, currentProject(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": workflow})
+}
+
+// Update Workflow godoc
+// @Summary Replace the task workflow of a project
+// @Description Status yang dihapus tidak boleh masih dipakai task. Rename status (dengan id) ikut diterapkan ke task.
+// @Tags Workflow
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer Token"
+// @Param project_id path uint true "Project ID"
+// @Param input body WorkflowInput true "Workflow"
+// @Success 200 {object} models.Workflow "Workflow updated"
+// @Failure 400 {object} utils.Problem "Invalid workflow"
+// @Failure 403 {object} utils.Problem "Forbidden"
+// @Failure 404 {object} utils.Problem "Project Not Found"
+// @Failure 409 {object} utils.Problem "Status still in use"
+// @Failure 500 {object} utils.Problem "Internal Server Error"
+// @Router /api/projects/{project_id}/workflow [put]
+func UpdateWorkflowController(c *gin.Context) {
+	var input WorkflowInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.Error(utils.Validation("invalid_request", err.Error()))
+		return
+	}
+
+	db := c.MustGet("db").(*gorm.DB)
+	userID := c.MustGet("user_id").(uint)
+
+	workflow := models.Workflow{}
+	for _, status := range input.Statuses {
+		workflow.Statuses = append(workflow.Statuses, models.WorkflowStatus{
+			ID: status.ID,
+			Name: status.Name,
+			Category: status.Category,
+		})
+	}
+	for _, transition := range input.Transitions {
+		workflow.Transitions = append(workflow.Transitions, models.WorkflowTransition{
+			From: transition.From,
+			To: transition.To,
+		})
+	}
+
+	updated, err := services.UpdateWorkflowService(db, currentProject(c), workflow, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"data": updated})
+}
@@ -19,7 +19,7 @@ func InitDB() (*gorm.DB, error) {
 		os.Getenv("PGDATABASE"),
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,37 @@ func InitDB() (*gorm.DB, error) {
 		&models.ProjectCollaborator{},
 		&models.Task{},
 		&models.TaskAssignment{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.WorkflowStatus{},
+		&models.WorkflowTransition{},
+		&models.TaskComment{},
+		&models.CommentMention{},
+		&models.TaskCommentEdit{},
+		&models.ChecklistItem{},
+		&models.TaskDependency{},
+		&models.Attachment{},
+		&models.AttachmentBlob{},
+		&models.TimeEntry{},
+		&models.Sprint{},
+		&models.SprintTaskSnapshot{},
+		&models.TaskStatusChange{},
+		&models.Label{},
+		&models.TaskLabel{},
+		&models.TaskEvent{},
+		&models.ProjectEvent{},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrateWorkflows(db); err != nil {
+		return nil, err
+	}
+	if err := migrateCompletedAt(db); err != nil {
+		return nil, err
+	}
+	err = migrateStatusHistory(db)
 
 	return db, err
 }
\ No newline at end of file
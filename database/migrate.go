@@ -0,0 +1,84 @@
+package database
+
+import (
+	"PA/models"
+	"PA/repository"
+
+	"gorm.io/gorm"
+)
+
+// migrateWorkflows membuat workflow bawaan untuk project lama lalu memetakan status teks bebas
+// pada task ke status workflow yang kategorinya paling mirip. Aman dijalankan berulang kali.
+func migrateWorkflows(db *gorm.DB) error {
+	var projectIDs []uint
+	err := db.Unscoped().Model(&models.Project{}).
+		Where("NOT EXISTS (SELECT 1 FROM workflow_statuses ws WHERE ws.project_id = projects.id)").
+		Pluck("id", &projectIDs).Error
+	if err != nil {
+		return err
+	}
+	for _, projectID := range projectIDs {
+		if err := repository.CreateDefaultWorkflow(db, projectID); err != nil {
+			return err
+		}
+	}
+
+	var tasks []struct {
+		ID        uint
+		ProjectID uint
+		Status    string
+	}
+	err = db.Unscoped().Model(&models.Task{}).
+		Select("id, project_id, status").
+		Where("NOT EXISTS (SELECT 1 FROM workflow_statuses ws WHERE ws.project_id = tasks.project_id AND ws.name = tasks.status)").
+		Scan(&tasks).Error
+	if err != nil {
+		return err
+	}
+
+	workflows := make(map[uint]models.Workflow)
+	for _, task := range tasks {
+		workflow, ok := workflows[task.ProjectID]
+		if !ok {
+			workflow, err = repository.GetWorkflow(db, task.ProjectID)
+			if err != nil {
+				return err
+			}
+			workflows[task.ProjectID] = workflow
+		}
+		if len(workflow.Statuses) == 0 {
+			continue
+		}
+
+		status, found := workflow.FindStatus(task.Status)
+		if !found {
+			status, found = workflow.FirstStatus(models.GuessStatusCategory(task.Status))
+		}
+		if !found {
+			status = workflow.Statuses[0]
+		}
+
+		if err := db.Unscoped().Model(&models.Task{}).Where("id = ?", task.ID).Update("status", status.Name).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateCompletedAt mengisi completed_at task lama yang sudah berstatus done dengan waktu update terakhirnya
+func migrateCompletedAt(db *gorm.DB) error {
+	return db.Unscoped().Model(&models.Task{}).
+		Where("completed_at IS NULL").
+		Where("EXISTS (SELECT 1 FROM workflow_statuses ws WHERE ws.project_id = tasks.project_id AND ws.name = tasks.status AND ws.category = ?)", models.StatusCategoryDone).
+		UpdateColumn("completed_at", gorm.Expr("updated_at")).Error
+}
+
+// migrateStatusHistory membuat riwayat awal untuk task yang belum memiliki riwayat status:
+// task dianggap berada di status saat ini sejak dibuat
+func migrateStatusHistory(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO task_status_changes (task_id, project_id, from_status, to_status, to_category, changed_at)
+		SELECT tasks.id, tasks.project_id, '', tasks.status, COALESCE(ws.category, ''), tasks.created_at
+		FROM tasks
+		LEFT JOIN workflow_statuses ws ON ws.project_id = tasks.project_id AND ws.name = tasks.status
+		WHERE NOT EXISTS (SELECT 1 FROM task_status_changes h WHERE h.task_id = tasks.id)`).Error
+}
@@ -0,0 +1,41 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+// NewCSV membuat Writer CSV. Output dibuffer oleh encoding/csv dan diteruskan ke out setiap buffer penuh.
+func NewCSV(out io.Writer) Writer {
+	return &csvWriter{w: csv.NewWriter(out)}
+}
+
+func (cw *csvWriter) WriteRow(values ...interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		text, isNumber := formatValue(value)
+		if !isNumber {
+			text = escapeFormula(text)
+		}
+		record[i] = text
+	}
+	return cw.w.Write(record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// escapeFormula mencegah teks bebas (misalnya note) dijalankan sebagai formula saat CSV dibuka di spreadsheet
+func escapeFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
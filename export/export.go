@@ -0,0 +1,65 @@
+// Package export menulis data tabular (misalnya timesheet) ke format CSV atau XLSX secara streaming,
+// baris demi baris, tanpa menampung seluruh data di memory.
+package export
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Format file yang didukung
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// Writer menulis satu baris setiap pemanggilan WriteRow. Nilai float64 dan int ditulis sebagai angka,
+// nilai lain sebagai teks. Close wajib dipanggil untuk menyelesaikan file.
+type Writer interface {
+	WriteRow(values ...interface{}) error
+	Close() error
+}
+
+// IsValidFormat memeriksa apakah format export didukung
+func IsValidFormat(format string) bool {
+	return format == FormatCSV || format == FormatXLSX
+}
+
+// ContentType mengembalikan MIME type untuk format export
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// New membuat Writer sesuai format yang menulis ke out
+func New(format string, out io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSV(out), nil
+	case FormatXLSX:
+		return NewXLSX(out, "Sheet1")
+	}
+	return nil, fmt.Errorf("format export tidak dikenal: %s", format)
+}
+
+// formatValue mengubah nilai sel menjadi teks, ok bernilai true jika nilai berupa angka
+func formatValue(value interface{}) (text string, ok bool) {
+	switch v := value.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), true
+	case string:
+		return v, false
+	case nil:
+		return "", false
+	}
+	return fmt.Sprint(value), false
+}
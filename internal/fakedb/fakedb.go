@@ -0,0 +1,132 @@
+// Package fakedb menyediakan driver database/sql minimal untuk menguji urutan statement repository
+// dan service tanpa PostgreSQL.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Statement adalah satu statement yang diterima DB, termasuk BEGIN/COMMIT/ROLLBACK
+type Statement struct {
+	SQL  string
+	Args []driver.NamedValue
+}
+
+// Result adalah jawaban DB untuk sebuah query: baris untuk SELECT/RETURNING, atau
+// jumlah baris yang terpengaruh untuk statement tanpa hasil
+type Result struct {
+	Columns      []string
+	Rows         [][]driver.Value
+	RowsAffected int64
+}
+
+// DB adalah connector database/sql yang mencatat setiap statement, dan respond menentukan hasilnya
+type DB struct {
+	mu         sync.Mutex
+	statements []Statement
+	respond    func(query string, args []driver.NamedValue) Result
+}
+
+// Open membuka *gorm.DB dialek postgres di atas DB. respond nil berarti semua query tidak menghasilkan baris.
+func Open(t testing.TB, respond func(query string, args []driver.NamedValue) Result) (*gorm.DB, *DB) {
+	t.Helper()
+	fake := &DB{respond: respond}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(fake)}), &gorm.Config{
+		Logger:               logger.Discard,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, fake
+}
+
+// Queries mengembalikan statement yang diawali prefix, misalnya `INSERT INTO "task_events"`
+func (f *DB) Queries(prefix string) []Statement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var found []Statement
+	for _, statement := range f.statements {
+		if strings.HasPrefix(statement.SQL, prefix) {
+			found = append(found, statement)
+		}
+	}
+	return found
+}
+
+// IndexOf mengembalikan posisi statement pertama yang diawali prefix, -1 jika tidak ada
+func (f *DB) IndexOf(prefix string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i, statement := range f.statements {
+		if strings.HasPrefix(statement.SQL, prefix) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *DB) record(query string, args []driver.NamedValue) Result {
+	f.mu.Lock()
+	f.statements = append(f.statements, Statement{SQL: query, Args: args})
+	f.mu.Unlock()
+	if f.respond == nil {
+		return Result{}
+	}
+	return f.respond(query, args)
+}
+
+func (f *DB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+func (f *DB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *DB }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.db.record("BEGIN", nil)
+	return c, nil
+}
+func (c *fakeConn) Commit() error {
+	c.db.record("COMMIT", nil)
+	return nil
+}
+func (c *fakeConn) Rollback() error {
+	c.db.record("ROLLBACK", nil)
+	return nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(c.db.record(query, args).RowsAffected), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	result := c.db.record(query, args)
+	return &fakeRows{columns: result.Columns, rows: result.Rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -3,10 +3,13 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 	
 	"github.com/joho/godotenv"
 	"PA/database"
 	"PA/routes"
+	"PA/services"
+	"PA/storage"
 )
 
 // @title Project Management API
@@ -27,7 +30,43 @@ func main() {
 		log.Fatal(err)
 	}
 
-	router := routes.SetupRouter(db)
+	if err := services.SyncSystemAdmins(db); err != nil {
+		log.Fatal(err)
+	}
+
+	store, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Hapus file attachment yang sudah tidak dipakai secara berkala
+	go func() {
+		for range time.Tick(time.Hour) {
+			if err := services.PurgeOrphanBlobs(db, store); err != nil {
+				log.Println("cleanup attachment gagal:", err)
+			}
+		}
+	}()
+
+	// Bersihkan token yang sudah kadaluarsa
+	go func() {
+		for range time.Tick(time.Hour) {
+			if err := services.PurgeExpiredTokens(db); err != nil {
+				log.Println("purge token gagal:", err)
+			}
+		}
+	}()
+
+	// Hapus permanen isi trash yang sudah melewati masa retensi
+	go func() {
+		for range time.Tick(time.Hour) {
+			if err := services.PurgeTrash(db); err != nil {
+				log.Println("purge trash gagal:", err)
+			}
+		}
+	}()
+
+	router := routes.SetupRouter(db, store)
 
 	port := os.Getenv("PORT")
 	
@@ -1,10 +1,11 @@
 package middleware
 
 import (
+    "PA/repository"
     "PA/utils"
-    "net/http"
     "strings"
     "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
 )
 
 // AuthMiddleware godoc
@@ -14,13 +15,13 @@ import (
 // @Security ApiKeyAuth
 // @Param Authorization header string true "Token JWT (Format: Bearer <token>)"
 // @Success 200 {object} map[string]interface{} "Token valid"
-// @Failure 401 {object} map[string]string "Token tidak valid atau tidak ada"
-// @Failure 500 {object} map[string]string "Kesalahan server internal"
+// @Failure 401 {object} utils.Problem "Token tidak valid atau tidak ada"
+// @Failure 500 {object} utils.Problem "Kesalahan server internal"
 func AuthMiddleware() gin.HandlerFunc {
     return func(c *gin.Context) {
         tokenString := c.GetHeader("Authorization")
         if tokenString == "" {
-            c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization Token is required"})
+            c.Error(utils.Unauthorized("missing_token", "Authorization Token is required"))
             c.Abort()
             return
         }
@@ -29,12 +30,34 @@ func AuthMiddleware() gin.HandlerFunc {
 
         token, user, err := utils.ParseJWT(tokenString)
         if err != nil || token == nil || !token.Valid {
-            c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+            c.Error(utils.Unauthorized("invalid_token", "Invalid token"))
+            c.Abort()
+            return
+        }
+
+        claims, err := utils.GetTokenClaims(token)
+        if err != nil {
+            c.Error(utils.Unauthorized("invalid_token", "Invalid token"))
+            c.Abort()
+            return
+        }
+
+        // Token yang sudah logout/dicabut ditolak walaupun belum kadaluarsa
+        db := c.MustGet("db").(*gorm.DB)
+        revoked, err := repository.IsAccessTokenRevoked(db, user.ID, claims)
+        if err != nil {
+            c.Error(err)
+            c.Abort()
+            return
+        }
+        if revoked {
+            c.Error(utils.Unauthorized("token_revoked", "Token has been revoked"))
             c.Abort()
             return
         }
 
         c.Set("user_id", user.ID)
+        c.Set("token_claims", claims)
         c.Next()
     }
 }
\ No newline at end of file
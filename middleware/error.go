@@ -0,0 +1,36 @@
+package middleware
+
+import (
+    "PA/utils"
+    "errors"
+    "log"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+)
+
+// ErrorHandler menerjemahkan error yang dicatat handler lewat c.Error menjadi response
+// application/problem+json. Harus dipasang sebelum middleware dan handler lainnya.
+func ErrorHandler() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        c.Next()
+
+        if len(c.Errors) == 0 || c.Writer.Written() {
+            return
+        }
+
+        err := c.Errors.Last().Err
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            err = utils.NotFound("not_found", "data tidak ditemukan")
+        }
+
+        problem := utils.NewProblem(err)
+        if problem.Status >= 500 {
+            log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+        }
+        problem.Instance = c.Request.URL.Path
+
+        c.Header("Content-Type", "application/problem+json")
+        c.JSON(problem.Status, problem)
+    }
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+    "PA/services"
+    "PA/utils"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "gorm.io/gorm"
+)
+
+// LoadProject memuat project dari parameter :project_id sekali per request beserta collaborator-nya,
+// memastikan user adalah anggota project, lalu menyimpannya di context dengan key "project".
+func LoadProject() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        projectID, err := strconv.ParseUint(c.Param("project_id"), 10, 64)
+        if err != nil {
+            c.Error(utils.Validation("invalid_project_id", "Invalid project ID"))
+            c.Abort()
+            return
+        }
+
+        db := c.MustGet("db").(*gorm.DB)
+        userID := c.MustGet("user_id").(uint)
+
+        project, err := services.GetProjectForUserService(db, uint(projectID), userID)
+        if err != nil {
+            c.Error(err)
+            c.Abort()
+            return
+        }
+
+        c.Set("project", project)
+        c.Next()
+    }
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// FlowStats adalah statistik durasi dalam jam
+type FlowStats struct {
+	Count int `json:"count"`
+	Average float64 `json:"average"`
+	P50 float64 `json:"p50"`
+	P85 float64 `json:"p85"`
+	P95 float64 `json:"p95"`
+}
+
+// FlowGroup adalah statistik lead time dan cycle time untuk satu assignee atau label
+type FlowGroup struct {
+	ID uint `json:"id"`
+	Name string `json:"name"`
+	LeadTime FlowStats `json:"lead_time"`
+	CycleTime FlowStats `json:"cycle_time"`
+}
+
+// FlowReport berisi lead time (dibuat sampai done) dan cycle time (pertama kali in progress sampai done)
+// task yang selesai di antara From dan To, dihitung dari riwayat status.
+type FlowReport struct {
+	ProjectID uint `json:"project_id"`
+	From time.Time `json:"from"`
+	To time.Time `json:"to"`
+	LeadTime FlowStats `json:"lead_time"`
+	CycleTime FlowStats `json:"cycle_time"`
+	ByAssignee []FlowGroup `json:"by_assignee"`
+	ByLabel []FlowGroup `json:"by_label"`
+}
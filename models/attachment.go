@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// @model
+type Attachment struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	TaskID uint `gorm:"not null;index" json:"task_id"`
+	UploaderID uint `gorm:"not null" json:"uploader_id"`
+	FileName string `gorm:"not null" json:"file_name"`
+	ContentType string `gorm:"not null" json:"content_type"`
+	Size int64 `gorm:"not null" json:"size"`
+	Checksum string `gorm:"size:64;not null;index" json:"checksum"` // sha256 isi file
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AttachmentBlob adalah isi file yang tersimpan di storage. Attachment dengan checksum yang sama
+// memakai blob yang sama, blob tanpa attachment dihapus oleh proses cleanup.
+type AttachmentBlob struct {
+	Checksum string `gorm:"primaryKey;size:64"`
+	Size int64 `gorm:"not null"`
+	ContentType string `gorm:"not null"`
+	CreatedAt time.Time
+	LastUsedAt time.Time `gorm:"not null;index"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// @model
+type TaskComment struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	TaskID uint `gorm:"not null;index" json:"task_id"`
+	AuthorID uint `gorm:"not null" json:"author_id"`
+	Author User `gorm:"foreignKey:AuthorID" json:"author"`
+	Body string `gorm:"type:text;not null" json:"body"`
+	Mentions []CommentMention `gorm:"foreignKey:CommentID" json:"mentions"`
+	EditedAt *time.Time `json:"edited_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	DeletedByID *uint `json:"-"`
+}
+
+// CommentMention adalah user anggota project yang di-mention (@username) di sebuah comment
+type CommentMention struct {
+	ID uint `gorm:"primaryKey" json:"-"`
+	CommentID uint `gorm:"not null;index" json:"-"`
+	UserID uint `gorm:"not null" json:"user_id"`
+	User User `gorm:"foreignKey:UserID" json:"user"`
+}
+
+// TaskCommentEdit menyimpan isi comment sebelum diedit
+type TaskCommentEdit struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	CommentID uint `gorm:"not null;index" json:"comment_id"`
+	Body string `gorm:"type:text;not null" json:"body"`
+	EditedByID uint `gorm:"not null" json:"edited_by_id"`
+	EditedAt time.Time `json:"edited_at"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// TaskDependency berarti task Blocker harus selesai sebelum task Blocked boleh masuk status kategori done.
+// Kedua task boleh berada di project yang berbeda.
+// @model
+type TaskDependency struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	BlockerID uint `gorm:"not null;uniqueIndex:idx_task_dependency" json:"blocker_id"`
+	Blocker Task `gorm:"foreignKey:BlockerID" json:"-"`
+	BlockedID uint `gorm:"not null;uniqueIndex:idx_task_dependency;index" json:"blocked_id"`
+	Blocked Task `gorm:"foreignKey:BlockedID" json:"-"`
+	CreatedByID uint `json:"created_by_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TaskLink adalah dependency dilihat dari salah satu task. Title dan status hanya diisi
+// jika user boleh melihat task di ujung lainnya.
+type TaskLink struct {
+	DependencyID uint `json:"dependency_id"`
+	TaskID uint `json:"task_id"`
+	ProjectID uint `json:"project_id,omitempty"`
+	Title string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// Jenis link yang dikirim saat membuat dependency, dilihat dari task di url
+const (
+	LinkBlocks    = "blocks"
+	LinkBlockedBy = "blocked_by"
+)
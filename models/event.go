@@ -0,0 +1,120 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Aksi yang dicatat pada riwayat task
+const (
+	TaskEventCreated  = "created"
+	TaskEventUpdated  = "updated"
+	TaskEventDeleted  = "deleted"
+	TaskEventRestored = "restored"
+	TaskEventAssigned = "assignees_changed"
+)
+
+// FieldChange adalah nilai sebuah field sebelum dan sesudah perubahan
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After interface{} `json:"after"`
+}
+
+// FieldChanges disimpan sebagai jsonb, key-nya adalah nama field JSON task
+type FieldChanges map[string]FieldChange
+
+func (c FieldChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	raw, err := json.Marshal(c)
+	return string(raw), err
+}
+
+func (c *FieldChanges) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = FieldChanges{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	}
+	return errors.New("FieldChanges: tipe kolom tidak didukung")
+}
+
+// TaskEvent adalah catatan append-only setiap perubahan task, ditulis dalam transaksi yang sama dengan perubahannya.
+// Event tetap disimpan walaupun task sudah dihapus.
+// @model
+type TaskEvent struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	TaskID uint `gorm:"not null;index" json:"task_id"`
+	ProjectID uint `gorm:"not null;index" json:"project_id"`
+	ActorID *uint `json:"actor_id"`
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	Action string `gorm:"not null" json:"action" enums:"created,updated,deleted,restored,assignees_changed"`
+	Changes FieldChanges `gorm:"type:jsonb;not null;default:'{}'" json:"changes" swaggertype:"object"`
+	CreatedAt time.Time `gorm:"not null;index" json:"created_at"`
+}
+
+// Aksi yang dicatat pada riwayat project
+const (
+	ProjectEventUpdated              = "updated"
+	ProjectEventRenamed              = "renamed"
+	ProjectEventCollaboratorAdded    = "collaborator_added"
+	ProjectEventCollaboratorRemoved  = "collaborator_removed"
+	ProjectEventRoleChanged          = "collaborator_role_changed"
+	ProjectEventDeleted              = "deleted"
+	ProjectEventRestored             = "restored"
+	ProjectEventArchived             = "archived"
+	ProjectEventUnarchived           = "unarchived"
+	ProjectEventOwnershipTransferred = "ownership_transferred"
+	ProjectEventDeleteAttempted      = "delete_attempted"
+)
+
+// Alasan percobaan hapus project yang gagal
+const (
+	DeleteAttemptDenied = "denied"
+	DeleteAttemptStale  = "stale_version"
+)
+
+// ProjectEvent adalah catatan append-only perubahan project itu sendiri (bukan task-nya).
+// TargetUserID diisi untuk perubahan collaborator, Note berisi alasan percobaan hapus yang gagal.
+// @model
+type ProjectEvent struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	ProjectID uint `gorm:"not null;index" json:"project_id"`
+	ActorID *uint `json:"actor_id"`
+	Actor *User `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	Action string `gorm:"not null" json:"action" enums:"updated,renamed,collaborator_added,collaborator_removed,collaborator_role_changed,deleted,restored,archived,unarchived,delete_attempted"`
+	TargetUserID *uint `json:"target_user_id"`
+	TargetUser *User `gorm:"foreignKey:TargetUserID" json:"target_user,omitempty"`
+	Changes FieldChanges `gorm:"type:jsonb;not null;default:'{}'" json:"changes" swaggertype:"object"`
+	Note string `json:"note,omitempty"`
+	CreatedAt time.Time `gorm:"not null;index" json:"created_at"`
+}
+
+// Sumber item activity feed
+const (
+	ActivityProject = "project"
+	ActivityTask    = "task"
+)
+
+// ActivityItem adalah satu event project atau task di activity feed beserta ringkasannya
+type ActivityItem struct {
+	Type string `json:"type" enums:"project,task"`
+	ID uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+	TaskID *uint `json:"task_id,omitempty"`
+	TaskTitle string `json:"task_title,omitempty"`
+	Actor *User `json:"actor,omitempty"`
+	Action string `json:"action"`
+	TargetUser *User `json:"target_user,omitempty"`
+	Changes FieldChanges `json:"changes" swaggertype:"object"`
+	Note string `json:"note,omitempty"`
+	Summary string `json:"summary"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// TaskStatusChange mencatat setiap perubahan status task. Kategori disimpan saat perubahan terjadi
+// sehingga laporan historis tidak berubah walaupun workflow diubah kemudian.
+// FromStatus kosong berarti task baru dibuat.
+// @model
+type TaskStatusChange struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	TaskID uint `gorm:"not null;index" json:"task_id"`
+	ProjectID uint `gorm:"not null;index" json:"project_id"`
+	FromStatus string `json:"from_status"`
+	ToStatus string `gorm:"not null" json:"to_status"`
+	ToCategory string `json:"to_category"`
+	ChangedByID *uint `json:"changed_by_id"`
+	ChangedAt time.Time `gorm:"not null;index" json:"changed_at"`
+}
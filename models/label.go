@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Label adalah penanda task yang didefinisikan per project
+// @model
+type Label struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	ProjectID uint `gorm:"not null;uniqueIndex:idx_project_label" json:"project_id"`
+	Name string `gorm:"not null;uniqueIndex:idx_project_label" json:"name"`
+	Color string `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// @model
+type TaskLabel struct {
+	TaskID uint `gorm:"primaryKey" json:"task_id"`
+	LabelID uint `gorm:"primaryKey;index" json:"label_id"`
+}
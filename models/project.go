@@ -1,6 +1,20 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Role collaborator di dalam project. Owner tidak disimpan sebagai collaborator,
+// melainkan diambil dari Project.OwnerID.
+const (
+	RoleOwner = "owner"
+	RoleAdmin = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+	RoleGuest = "guest"
+)
 
 // @model
 type Project struct {
@@ -10,7 +24,21 @@ type Project struct {
 	OwnerID uint `gorm:"not null" json:"owner_id"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version uint `gorm:"not null;default:1" json:"version"`
+	ArchivedAt *time.Time `gorm:"index" json:"archived_at"` // project yang diarsipkan hanya bisa dibaca
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // project di trash, dihapus permanen setelah masa retensi
+	DeletedByID *uint `json:"-"`
 	Collaborators []ProjectCollaborator `gorm:"foreignKey:ProjectID" json:"collaborators"`
+	Role string `gorm:"-" json:"role,omitempty"`
+	TaskSummary *TaskSummary `gorm:"-" json:"task_summary,omitempty"`
+}
+
+// TaskSummary adalah jumlah task sebuah project per kategori status workflow
+type TaskSummary struct {
+	Total int64 `json:"total"`
+	Todo int64 `json:"todo"`
+	InProgress int64 `json:"in_progress"`
+	Done int64 `json:"done"`
 }
 
 // @model
@@ -19,5 +47,15 @@ type ProjectCollaborator struct {
 	ProjectID uint `gorm:"not null;index;constraint:OnDelete:CASCADE" json:"project_id"`
 	UserID uint `gorm:"not null" json:"user_id"`
 	User User `gorm:"foreignKey:UserID" json:"user"`
+	Role string `gorm:"not null;default:member" json:"role"`
 	CreatedAt time.Time `json:"created_at"`
-}
\ No newline at end of file
+}
+
+// IsValidCollaboratorRole mengecek role yang boleh diberikan ke collaborator
+func IsValidCollaboratorRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleMember, RoleViewer, RoleGuest:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// ListOptions berisi parameter cursor pagination dan sorting untuk endpoint list
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	Sort   string
+	Desc   bool
+}
+
+// TaskFilter berisi filter daftar task, field kosong berarti tidak difilter
+type TaskFilter struct {
+	Status         string
+	AssigneeID     uint
+	DeadlineBefore *time.Time
+	DeadlineAfter  *time.Time
+	Query          string
+	SprintID       uint
+	Backlog        bool // hanya task yang belum masuk sprint
+}
+
+// Nilai filter membership pada daftar project
+const (
+	MembershipOwned  = "owned"
+	MembershipShared = "shared"
+	MembershipAll    = "all"
+)
+
+// Nilai filter archived pada daftar project
+const (
+	ArchivedExclude = "false"
+	ArchivedOnly    = "true"
+	ArchivedAll     = "all"
+)
+
+// ProjectFilter berisi filter daftar project
+type ProjectFilter struct {
+	Query      string
+	Membership string
+	Archived   string
+}
+
+// IsValidArchivedFilter mengecek nilai parameter archived
+func IsValidArchivedFilter(archived string) bool {
+	switch archived {
+	case ArchivedExclude, ArchivedOnly, ArchivedAll:
+		return true
+	}
+	return false
+}
+
+// IsValidMembership mengecek nilai parameter membership
+func IsValidMembership(membership string) bool {
+	switch membership {
+	case MembershipOwned, MembershipShared, MembershipAll:
+		return true
+	}
+	return false
+}
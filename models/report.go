@@ -0,0 +1,39 @@
+package models
+
+// Satuan sisa pekerjaan pada burndown
+const (
+	BurndownPoints = "points"
+	BurndownTasks = "tasks"
+	BurndownHours = "hours"
+)
+
+// Burndown adalah sisa pekerjaan sprint per hari, dihitung dari riwayat status task.
+// Remaining bernilai null untuk hari yang belum terjadi.
+type Burndown struct {
+	SprintID uint `json:"sprint_id"`
+	Unit string `json:"unit" enums:"points,tasks,hours"`
+	Days []BurndownDay `json:"days"`
+}
+
+type BurndownDay struct {
+	Date string `json:"date"`
+	Total *float64 `json:"total"` // seluruh pekerjaan sprint yang sudah ada pada hari itu
+	Remaining *float64 `json:"remaining"` // pekerjaan yang belum berkategori done pada akhir hari
+	Ideal float64 `json:"ideal"`
+}
+
+// CumulativeFlow adalah jumlah task per status pada akhir setiap hari
+type CumulativeFlow struct {
+	Statuses []CumulativeFlowStatus `json:"statuses"`
+	Days []CumulativeFlowDay `json:"days"`
+}
+
+type CumulativeFlowStatus struct {
+	Name string `json:"name"`
+	Category string `json:"category"`
+}
+
+type CumulativeFlowDay struct {
+	Date string `json:"date"`
+	Counts map[string]int64 `json:"counts"`
+}
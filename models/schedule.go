@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// DefaultDurationDays adalah durasi yang dipakai schedule untuk task yang belum memiliki duration_days
+const DefaultDurationDays = 1
+
+// Schedule adalah hasil perhitungan critical path (CPM) sebuah project dalam bentuk yang siap dirender sebagai Gantt chart.
+// Semua tanggal finish bersifat eksklusif (task selesai sebelum tanggal tersebut).
+type Schedule struct {
+	ProjectID uint `json:"project_id"`
+	Start time.Time `json:"start"`
+	Finish time.Time `json:"finish"`
+	DurationDays int `json:"duration_days"`
+	DefaultDurationDays int `json:"default_duration_days"` // durasi yang dipakai untuk task dengan estimate_missing
+	MissingEstimates int `json:"missing_estimates"` // jumlah task yang belum memiliki duration_days
+	CriticalPath []uint `json:"critical_path"`
+	Tasks []ScheduledTask `json:"tasks"`
+}
+
+// ScheduledTask adalah satu baris Gantt chart
+type ScheduledTask struct {
+	ID uint `json:"id"`
+	Title string `json:"title"`
+	Status string `json:"status"`
+	ParentID *uint `json:"parent_id"`
+	DurationDays int `json:"duration_days"`
+	EstimateMissing bool `json:"estimate_missing"` // durasi belum diestimasi, dihitung DefaultDurationDays
+	Predecessors []uint `json:"predecessors"`
+	EarliestStart time.Time `json:"earliest_start"`
+	EarliestFinish time.Time `json:"earliest_finish"`
+	LatestStart time.Time `json:"latest_start"`
+	LatestFinish time.Time `json:"latest_finish"`
+	SlackDays int `json:"slack_days"`
+	Critical bool `json:"critical"`
+	Deadline *time.Time `json:"deadline"`
+	DeadlineSlackDays *int `json:"deadline_slack_days"` // sisa hari antara earliest finish dan deadline, negatif jika terlambat
+	Late bool `json:"late"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// State sprint. Setiap project hanya boleh memiliki satu sprint active.
+const (
+	SprintPlanned = "planned"
+	SprintActive = "active"
+	SprintClosed = "closed"
+)
+
+// @model
+type Sprint struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	ProjectID uint `gorm:"not null;index;uniqueIndex:idx_active_sprint,where:state = 'active'" json:"project_id"`
+	Name string `gorm:"not null" json:"name"`
+	Goal string `json:"goal"`
+	StartDate time.Time `gorm:"not null" json:"start_date"`
+	EndDate time.Time `gorm:"not null" json:"end_date"`
+	State string `gorm:"not null;default:planned" json:"state" enums:"planned,active,closed"`
+	ClosedAt *time.Time `json:"closed_at"`
+	ClosedByID *uint `json:"closed_by_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Summary *SprintSummary `gorm:"-" json:"summary,omitempty"`
+	Snapshot []SprintTaskSnapshot `gorm:"foreignKey:SprintID" json:"snapshot,omitempty"`
+}
+
+// SprintSummary adalah jumlah task dan story point sebuah sprint. Untuk sprint yang sudah closed
+// nilainya diambil dari snapshot saat sprint ditutup.
+type SprintSummary struct {
+	TotalTasks int64 `json:"total_tasks"`
+	CompletedTasks int64 `json:"completed_tasks"`
+	TotalPoints int64 `json:"total_points"`
+	CompletedPoints int64 `json:"completed_points"`
+	CompletedHours float64 `json:"completed_hours"`
+}
+
+// SprintTaskSnapshot mencatat keadaan task saat sprint ditutup. Judul dan estimasi disalin
+// agar laporan tetap utuh walaupun task diubah atau dihapus setelahnya.
+// @model
+type SprintTaskSnapshot struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	SprintID uint `gorm:"not null;index" json:"sprint_id"`
+	TaskID uint `gorm:"not null" json:"task_id"`
+	Title string `json:"title"`
+	Status string `json:"status"`
+	StoryPoints *int `json:"story_points"`
+	EstimatedHours *float64 `json:"estimated_hours"`
+	Completed bool `gorm:"not null" json:"completed"`
+	CompletedAt *time.Time `json:"completed_at"`
+	MovedToSprintID *uint `json:"moved_to_sprint_id"` // nil untuk task selesai atau yang dikembalikan ke backlog
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// MaxTaskDepth adalah kedalaman maksimum hierarki task (task utama dihitung level 1)
+const MaxTaskDepth = 3
+
+// @model
+type ChecklistItem struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	TaskID uint `gorm:"not null;index" json:"task_id"`
+	Title string `gorm:"not null" json:"title"`
+	Done bool `gorm:"not null;default:false" json:"done"`
+	DoneAt *time.Time `json:"done_at"`
+	Position int `gorm:"not null;default:0" json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TaskProgress adalah progress task yang dihitung dari subtask (status kategori done) dan checklist.
+// Percent memakai subtask jika ada, selain itu memakai checklist.
+type TaskProgress struct {
+	SubtasksTotal int `json:"subtasks_total"`
+	SubtasksDone int `json:"subtasks_done"`
+	ChecklistTotal int `json:"checklist_total"`
+	ChecklistDone int `json:"checklist_done"`
+	Percent int `json:"percent"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+    "time"
+
+    "gorm.io/gorm"
+)
 
 // @model
 type Task struct {
@@ -13,6 +17,23 @@ type Task struct {
     Assignments []TaskAssignment `gorm:"foreignKey:TaskID" json:"-"`
     AssignedTo []UserResponse `gorm:"-" json:"assigned_to"`
     Deadline time.Time `json:"deadline"`
+    Version uint `gorm:"not null;default:1" json:"version"`
+    CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+    UpdatedAt time.Time `json:"updated_at"`
+    ParentID *uint `gorm:"index" json:"parent_id"`
+    SprintID *uint `gorm:"index" json:"sprint_id"` // nil berarti task ada di backlog
+    DurationDays int `gorm:"not null;default:0" json:"duration_days"` // estimasi durasi pengerjaan dalam hari, 0 = belum diestimasi
+    StoryPoints *int `json:"story_points"`
+    EstimatedHours *float64 `json:"estimated_hours"`
+    CompletedAt *time.Time `gorm:"index" json:"completed_at"` // diisi saat task masuk status berkategori done
+    DeletedAt gorm.DeletedAt `gorm:"index" json:"-"` // task di trash, dihapus permanen setelah masa retensi
+    DeletedByID *uint `json:"-"`
+    Subtasks []Task `gorm:"-" json:"subtasks,omitempty"`
+    Checklist []ChecklistItem `gorm:"-" json:"checklist,omitempty"`
+    Progress *TaskProgress `gorm:"-" json:"progress,omitempty"`
+    Labels []Label `gorm:"-" json:"labels,omitempty"`
+    Blocks []TaskLink `gorm:"-" json:"blocks,omitempty"`
+    BlockedBy []TaskLink `gorm:"-" json:"blocked_by,omitempty"`
 }
 
 // @model
@@ -24,6 +45,20 @@ type TaskAssignment struct {
     AssignedAt time.Time `json:"assigned_at"`
 }
 
+// TaskPatch berisi perubahan task, field nil berarti tidak diubah.
+// AssignedTo yang tidak nil menggantikan seluruh daftar assignee.
+// StoryPoints dan EstimatedHours yang menunjuk ke nil berarti estimasi dikosongkan.
+type TaskPatch struct {
+    Title          *string
+    Description    *string
+    Status         *string
+    Deadline       *time.Time
+    DurationDays   *int
+    StoryPoints    **int
+    EstimatedHours **float64
+    AssignedTo     *[]uint
+}
+
 // User response digunakan agar saat response ok (200) hanya memunculkan username dan email
 
 type UserResponse struct {
@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// Sumber time entry
+const (
+	TimeSourceTimer  = "timer"
+	TimeSourceManual = "manual"
+)
+
+// TimeEntry adalah waktu kerja user pada sebuah task. Entry dengan EndedAt nil adalah timer yang sedang berjalan,
+// setiap user hanya boleh memiliki satu timer berjalan.
+// @model
+type TimeEntry struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	TaskID uint `gorm:"not null;index" json:"task_id"`
+	UserID uint `gorm:"not null;index;uniqueIndex:idx_running_timer,where:ended_at IS NULL" json:"user_id"`
+	StartedAt time.Time `gorm:"not null;index" json:"started_at"`
+	EndedAt *time.Time `json:"ended_at"`
+	DurationSeconds int64 `gorm:"not null;default:0" json:"duration_seconds"`
+	Note string `json:"note"`
+	Source string `gorm:"not null;default:manual" json:"source" enums:"timer,manual"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TimeRange membatasi time entry berdasarkan started_at, nil berarti tidak dibatasi
+type TimeRange struct {
+	From *time.Time
+	To *time.Time
+}
+
+// TimeTotal adalah total waktu untuk satu user, task atau project
+type TimeTotal struct {
+	ID uint `json:"id"`
+	Name string `json:"name"`
+	Seconds int64 `json:"seconds"`
+}
+
+// TimeSummary adalah total waktu (hanya entry yang sudah selesai) beserta rinciannya
+type TimeSummary struct {
+	TotalSeconds int64 `json:"total_seconds"`
+	ByUser []TimeTotal `json:"by_user,omitempty"`
+	ByTask []TimeTotal `json:"by_task,omitempty"`
+	ByProject []TimeTotal `json:"by_project,omitempty"`
+}
+
+// Pengelompokan baris timesheet
+const (
+	TimesheetGroupDay  = "day"
+	TimesheetGroupTask = "task"
+	TimesheetGroupUser = "user"
+)
+
+func IsValidTimesheetGroup(groupBy string) bool {
+	return groupBy == TimesheetGroupDay || groupBy == TimesheetGroupTask || groupBy == TimesheetGroupUser
+}
+
+// TimesheetFilter membatasi baris timesheet. ProjectID atau UserID 0 berarti tidak dibatasi.
+type TimesheetFilter struct {
+	ProjectID uint
+	UserID uint
+	Range TimeRange
+	GroupBy string
+}
+
+// TimesheetRow adalah satu time entry yang sudah selesai beserta user, task dan project-nya
+type TimesheetRow struct {
+	EntryID uint
+	StartedAt time.Time
+	EndedAt time.Time
+	DurationSeconds int64
+	Note string
+	UserID uint
+	Username string
+	ProjectID uint
+	ProjectName string
+	TaskID uint
+	TaskTitle string
+}
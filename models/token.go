@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// @model
+type RefreshToken struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	TokenHash string `gorm:"uniqueIndex;not null" json:"-"`
+	FamilyID string `gorm:"not null;index" json:"-"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	ReplacedByID *uint `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RevokedToken menyimpan jti access token yang dicabut sebelum kadaluarsa
+type RevokedToken struct {
+	JTI string `gorm:"primaryKey;size:64" json:"jti"`
+	UserID uint `gorm:"not null" json:"user_id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuthTokens adalah response login dan refresh token
+type AuthTokens struct {
+	Token string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// Input untuk refresh token dan logout
+type RefreshTokenInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutInput struct {
+	All bool `json:"all"`
+}
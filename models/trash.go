@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Jenis item di trash
+const (
+	TrashProject = "project"
+	TrashTask    = "task"
+)
+
+// TrashItem adalah project atau task di trash. Task yang ikut terhapus bersama project atau task induknya
+// tidak ditampilkan sendiri karena akan ikut dipulihkan bersama induknya.
+type TrashItem struct {
+	Type string `json:"type" enums:"project,task"`
+	ID uint `json:"id"`
+	Name string `json:"name"`
+	ProjectID uint `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	DeletedAt time.Time `json:"deleted_at"`
+	DeletedByID *uint `json:"deleted_by_id"`
+	DeletedBy string `json:"deleted_by"`
+	TaskCount int64 `json:"task_count"` // jumlah task yang ikut dipulihkan (project: seluruh task-nya, task: subtask-nya)
+	PurgeAt time.Time `json:"purge_at"`
+	Role string `json:"-"`
+}
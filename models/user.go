@@ -8,6 +8,8 @@ type User struct {
 	Username string `gorm:"unique;not null" json:"username"`
 	Email string `gorm:"unique;not null" json:"email"`
 	Password string `gorm:"not null" json:"-"`
+	TokensRevokedAt *time.Time `json:"-"` // access token yang diterbitkan sebelum waktu ini ditolak
+	IsAdmin bool `gorm:"not null;default:false" json:"-"` // admin sistem dari ADMIN_USERS, boleh memindahkan kepemilikan project mana pun
 	CreatedAt time.Time `json:"-"`
 	UpdatedAt time.Time `json:"-"`
 }
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// Pengelompokan report velocity
+const (
+	VelocityByWindow = "window"
+	VelocityBySprint = "sprint"
+)
+
+// VelocityReport adalah jumlah task, story point dan jam estimasi yang diselesaikan per window waktu
+type VelocityReport struct {
+	ProjectID uint `json:"project_id"`
+	From time.Time `json:"from"`
+	To time.Time `json:"to"`
+	WindowDays int `json:"window_days,omitempty"`
+	By string `json:"by" enums:"window,sprint"`
+	Windows []VelocityWindow `json:"windows"`
+	AveragePoints float64 `json:"average_points"`
+	AverageHours float64 `json:"average_hours"`
+	AverageTasks float64 `json:"average_tasks"`
+}
+
+// VelocityWindow adalah hasil satu window, End bersifat eksklusif. Pada report per sprint
+// setiap window adalah satu sprint yang sudah closed.
+type VelocityWindow struct {
+	SprintID *uint `json:"sprint_id,omitempty"`
+	SprintName string `json:"sprint_name,omitempty"`
+	Start time.Time `json:"start"`
+	End time.Time `json:"end"`
+	CompletedTasks int64 `json:"completed_tasks"`
+	CompletedPoints int64 `json:"completed_points"`
+	CompletedHours float64 `json:"completed_hours"`
+	UnestimatedTasks int64 `json:"unestimated_tasks"` // task selesai tanpa story point
+}
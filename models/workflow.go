@@ -0,0 +1,118 @@
+package models
+
+import "strings"
+
+// Kategori status workflow, dipakai untuk laporan dan aturan yang tidak bergantung pada nama status
+const (
+	StatusCategoryTodo = "todo"
+	StatusCategoryInProgress = "in_progress"
+	StatusCategoryDone = "done"
+)
+
+// @model
+type WorkflowStatus struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	ProjectID uint `gorm:"not null;index" json:"project_id"`
+	Name string `gorm:"not null" json:"name"`
+	Category string `gorm:"not null" json:"category"`
+	Position int `gorm:"not null;default:0" json:"position"`
+}
+
+// @model
+type WorkflowTransition struct {
+	ID uint `gorm:"primaryKey" json:"-"`
+	ProjectID uint `gorm:"not null;index" json:"-"`
+	FromStatusID uint `gorm:"not null" json:"from_status_id"`
+	ToStatusID uint `gorm:"not null" json:"to_status_id"`
+	From string `gorm:"-" json:"from"`
+	To string `gorm:"-" json:"to"`
+}
+
+// Workflow adalah kumpulan status berurutan dan transisi yang diizinkan di sebuah project
+type Workflow struct {
+	Statuses []WorkflowStatus `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+// FindStatus mencari status berdasarkan nama (tidak case sensitive)
+func (w Workflow) FindStatus(name string) (WorkflowStatus, bool) {
+	name = strings.TrimSpace(name)
+	for _, status := range w.Statuses {
+		if strings.EqualFold(status.Name, name) {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+// FirstStatus mengembalikan status pertama dengan kategori tertentu sesuai urutan position
+func (w Workflow) FirstStatus(category string) (WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Category == category {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+func (w Workflow) CanTransition(fromID, toID uint) bool {
+	if fromID == toID {
+		return true
+	}
+	for _, transition := range w.Transitions {
+		if transition.FromStatusID == fromID && transition.ToStatusID == toID {
+			return true
+		}
+	}
+	return false
+}
+
+// CategoryOf mengembalikan kategori dari nama status, string kosong jika status tidak dikenal
+func (w Workflow) CategoryOf(name string) string {
+	status, ok := w.FindStatus(name)
+	if !ok {
+		return ""
+	}
+	return status.Category
+}
+
+func IsValidStatusCategory(category string) bool {
+	switch category {
+	case StatusCategoryTodo, StatusCategoryInProgress, StatusCategoryDone:
+		return true
+	}
+	return false
+}
+
+// DefaultWorkflow adalah workflow yang dibuat untuk project baru. Transisi memakai nama status.
+func DefaultWorkflow() Workflow {
+	return Workflow{
+		Statuses: []WorkflowStatus{
+			{Name: "To Do", Category: StatusCategoryTodo, Position: 0},
+			{Name: "In Progress", Category: StatusCategoryInProgress, Position: 1},
+			{Name: "Done", Category: StatusCategoryDone, Position: 2},
+		},
+		Transitions: []WorkflowTransition{
+			{From: "To Do", To: "In Progress"},
+			{From: "To Do", To: "Done"},
+			{From: "In Progress", To: "To Do"},
+			{From: "In Progress", To: "Done"},
+			{From: "Done", To: "In Progress"},
+		},
+	}
+}
+
+// GuessStatusCategory menebak kategori dari status teks bebas, dipakai saat migrasi data lama
+func GuessStatusCategory(status string) string {
+	normalized := strings.ToLower(strings.TrimSpace(status))
+	normalized = strings.NewReplacer("_", " ", "-", " ").Replace(normalized)
+
+	switch normalized {
+	case "done", "selesai", "complete", "completed", "finished", "closed", "resolved", "beres":
+		return StatusCategoryDone
+	case "in progress", "progress", "doing", "ongoing", "wip", "proses", "diproses", "dikerjakan",
+		"sedang dikerjakan", "review", "in review", "testing":
+		return StatusCategoryInProgress
+	}
+	return StatusCategoryTodo
+}
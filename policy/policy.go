@@ -0,0 +1,132 @@
+package policy
+
+import (
+	"PA/models"
+	"PA/utils"
+)
+
+// Action adalah operasi yang bisa dilakukan user terhadap project atau task
+type Action string
+
+const (
+	ViewProject         Action = "project:view"
+	UpdateProject       Action = "project:update"
+	DeleteProject       Action = "project:delete"
+	ArchiveProject      Action = "project:archive"
+	TransferOwnership   Action = "project:transfer_ownership"
+	ManageCollaborators Action = "project:manage_collaborators"
+	ManageWorkflow      Action = "project:manage_workflow"
+	ListTasks           Action = "task:list"
+	ViewAllTasks        Action = "task:view_all"
+	ViewTask            Action = "task:view"
+	CreateTask          Action = "task:create"
+	UpdateTask          Action = "task:update"
+	DeleteTask          Action = "task:delete"
+	ModerateComments    Action = "comment:moderate"
+	ManageSprints       Action = "project:manage_sprints"
+)
+
+var ErrForbidden = utils.Forbidden("forbidden", "anda tidak memiliki akses untuk melakukan aksi ini")
+
+// matrix hak akses per role. Guest tidak memiliki ViewAllTasks, sehingga ViewTask untuk guest
+// dibatasi ke task yang di-assign (lihat Can).
+var matrix = map[string]map[Action]bool{
+	models.RoleOwner: {
+		ViewProject: true, UpdateProject: true, DeleteProject: true, ArchiveProject: true, TransferOwnership: true, ManageCollaborators: true, ManageWorkflow: true,
+		ListTasks: true, ViewAllTasks: true, ViewTask: true, CreateTask: true, UpdateTask: true, DeleteTask: true,
+		ModerateComments: true, ManageSprints: true,
+	},
+	models.RoleAdmin: {
+		ViewProject: true, UpdateProject: true, ManageCollaborators: true, ManageWorkflow: true,
+		ListTasks: true, ViewAllTasks: true, ViewTask: true, CreateTask: true, UpdateTask: true, DeleteTask: true,
+		ModerateComments: true, ManageSprints: true,
+	},
+	models.RoleMember: {
+		ViewProject: true,
+		ListTasks:   true, ViewAllTasks: true, ViewTask: true, CreateTask: true, UpdateTask: true,
+	},
+	models.RoleViewer: {
+		ViewProject: true,
+		ListTasks:   true, ViewAllTasks: true, ViewTask: true,
+	},
+	models.RoleGuest: {
+		ViewProject: true,
+		ListTasks:   true, ViewTask: true,
+	},
+}
+
+// roles adalah semua role yang ada di matrix, urut dari hak akses terbesar
+var roles = []string{models.RoleOwner, models.RoleAdmin, models.RoleMember, models.RoleViewer, models.RoleGuest}
+
+// RolesWith mengembalikan role yang boleh melakukan action, untuk query lintas project yang tidak
+// bisa mengecek Can per resource
+func RolesWith(action Action) []string {
+	allowed := []string{}
+	for _, role := range roles {
+		if Allows(role, action) {
+			allowed = append(allowed, role)
+		}
+	}
+	return allowed
+}
+
+// RoleOf mengembalikan role user di project, string kosong jika bukan anggota.
+// Collaborators harus sudah di-preload.
+func RoleOf(project *models.Project, userID uint) string {
+	if project.OwnerID == userID {
+		return models.RoleOwner
+	}
+	for _, collab := range project.Collaborators {
+		if collab.UserID == userID {
+			return collab.Role
+		}
+	}
+	return ""
+}
+
+// Allows mengecek matrix tanpa melihat resource
+func Allows(role string, action Action) bool {
+	return matrix[role][action]
+}
+
+// Can memutuskan apakah user boleh melakukan action terhadap resource.
+// Resource yang didukung adalah *models.Project dan *models.Task (dengan Project.Collaborators
+// dan Assignments sudah di-preload).
+func Can(userID uint, action Action, resource interface{}) bool {
+	switch r := resource.(type) {
+	case *models.Project:
+		return Allows(RoleOf(r, userID), action)
+	case *models.Task:
+		role := RoleOf(&r.Project, userID)
+		if !Allows(role, action) {
+			return false
+		}
+		if action == ViewTask && !Allows(role, ViewAllTasks) {
+			return IsAssigned(r, userID)
+		}
+		return true
+	}
+	return false
+}
+
+// Authorize sama seperti Can tetapi mengembalikan ErrForbidden
+func Authorize(userID uint, action Action, resource interface{}) error {
+	if !Can(userID, action, resource) {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func IsAssigned(task *models.Task, userID uint) bool {
+	for _, assignment := range task.Assignments {
+		if assignment.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMember mengecek apakah user merupakan owner atau collaborator project
+func IsMember(project *models.Project, userID uint) bool {
+	return RoleOf(project, userID) != ""
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"PA/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RecordProjectEvent menambahkan event ke riwayat project atas nama actorID (0 = sistem)
+func RecordProjectEvent(db *gorm.DB, event models.ProjectEvent, actorID uint) error {
+	if actorID != 0 {
+		event.ActorID = &actorID
+	}
+	if event.Changes == nil {
+		event.Changes = models.FieldChanges{}
+	}
+	event.CreatedAt = time.Now()
+	return db.Create(&event).Error
+}
+
+// activityAfter adalah kondisi keyset untuk satu sumber feed. Feed diurutkan created_at, sumber, lalu id
+// secara descending, sehingga untuk sumber yang sama dengan cursor dipakai id sebagai pembanding.
+func activityAfter(table, kind string, after *cursor, at time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if after == nil {
+			return db
+		}
+		switch {
+		case kind < after.Kind:
+			return db.Where(table+".created_at <= ?", at)
+		case kind == after.Kind:
+			return db.Where("("+table+".created_at < ? OR ("+table+".created_at = ? AND "+table+".id < ?))", at, at, after.ID)
+		default:
+			return db.Where(table+".created_at < ?", at)
+		}
+	}
+}
+
+// GetProjectActivity menggabungkan riwayat project dan riwayat seluruh task-nya, terbaru dulu, dengan
+// keyset pagination. Setiap sumber diambil limit+1 baris lalu digabung di memori. assigneeID selain 0
+// membatasi riwayat task ke task yang saat ini di-assign ke user tersebut.
+func GetProjectActivity(db *gorm.DB, projectID, assigneeID uint, opts models.ListOptions) ([]models.ActivityItem, string, error) {
+	var after *cursor
+	var at time.Time
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+		if err != nil || c.Kind == "" {
+			return nil, "", ErrInvalidCursor
+		}
+		at, err = time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, "", ErrInvalidCursor
+		}
+		after = &c
+	}
+	limit := pageLimit(opts)
+
+	var projectEvents []models.ProjectEvent
+	err := db.Scopes(activityAfter("project_events", models.ActivityProject, after, at)).
+		Preload("Actor", selectUser).
+		Preload("TargetUser", selectUser).
+		Where("project_events.project_id = ?", projectID).
+		Order("project_events.created_at DESC, project_events.id DESC").
+		Limit(limit + 1).
+		Find(&projectEvents).Error
+	if err != nil {
+		return nil, "", err
+	}
+
+	taskQuery := db.Scopes(activityAfter("task_events", models.ActivityTask, after, at)).
+		Preload("Actor", selectUser).
+		Where("task_events.project_id = ?", projectID)
+	if assigneeID != 0 {
+		taskQuery = taskQuery.Where("task_events.task_id IN (SELECT task_id FROM task_assignments WHERE user_id = ?)", assigneeID)
+	}
+	var taskEvents []models.TaskEvent
+	err = taskQuery.
+		Order("task_events.created_at DESC, task_events.id DESC").
+		Limit(limit + 1).
+		Find(&taskEvents).Error
+	if err != nil {
+		return nil, "", err
+	}
+
+	titles, err := taskTitles(db, taskEvents)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items := make([]models.ActivityItem, 0, len(projectEvents)+len(taskEvents))
+	for _, event := range projectEvents {
+		items = append(items, models.ActivityItem{
+			Type:       models.ActivityProject,
+			ID:         event.ID,
+			ProjectID:  event.ProjectID,
+			Actor:      event.Actor,
+			Action:     event.Action,
+			TargetUser: event.TargetUser,
+			Changes:    event.Changes,
+			Note:       event.Note,
+			CreatedAt:  event.CreatedAt,
+		})
+	}
+	for _, event := range taskEvents {
+		taskID := event.TaskID
+		items = append(items, models.ActivityItem{
+			Type:      models.ActivityTask,
+			ID:        event.ID,
+			ProjectID: event.ProjectID,
+			TaskID:    &taskID,
+			TaskTitle: titles[event.TaskID],
+			Actor:     event.Actor,
+			Action:    event.Action,
+			Changes:   event.Changes,
+			CreatedAt: event.CreatedAt,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		if a.Type != b.Type {
+			return a.Type > b.Type
+		}
+		return a.ID > b.ID
+	})
+
+	if len(items) <= limit {
+		return items, "", nil
+	}
+	last := items[limit-1]
+	next := encodeCursor(cursor{
+		Sort:  DefaultSort,
+		Desc:  true,
+		Value: last.CreatedAt.UTC().Format(time.RFC3339Nano),
+		ID:    last.ID,
+		Kind:  last.Type,
+	})
+	return items[:limit], next, nil
+}
+
+// taskTitles mengembalikan judul task saat ini untuk event yang task-nya masih ada
+func taskTitles(db *gorm.DB, events []models.TaskEvent) (map[uint]string, error) {
+	titles := map[uint]string{}
+	if len(events) == 0 {
+		return titles, nil
+	}
+	ids := make([]uint, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.TaskID)
+	}
+
+	var tasks []models.Task
+	if err := db.Select("id", "title").Where("id IN ?", ids).Find(&tasks).Error; err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		titles[task.ID] = task.Title
+	}
+	return titles, nil
+}
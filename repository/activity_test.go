@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"PA/internal/fakedb"
+	"PA/models"
+)
+
+func TestGetProjectActivityAssigneeFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		assigneeID uint
+		filtered   bool
+	}{
+		{"semua task", 0, false},
+		{"hanya task yang di-assign", 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := fakedb.Open(t, nil)
+			if _, _, err := GetProjectActivity(db, 3, tt.assigneeID, models.ListOptions{}); err != nil {
+				t.Fatal(err)
+			}
+
+			queries := fake.Queries(`SELECT * FROM "task_events"`)
+			if len(queries) != 1 {
+				t.Fatalf("jumlah query task_events = %d, want 1", len(queries))
+			}
+			query := queries[0]
+			filtered := strings.Contains(query.SQL, "task_assignments WHERE user_id")
+			if filtered != tt.filtered {
+				t.Errorf("filter assignee = %v, want %v: %s", filtered, tt.filtered, query.SQL)
+			}
+			if tt.filtered && !hasArg(query.Args, int64(tt.assigneeID)) {
+				t.Errorf("argumen %v tidak berisi assignee %d", query.Args, tt.assigneeID)
+			}
+			if projectQuery := fake.Queries(`SELECT * FROM "project_events"`); len(projectQuery) != 1 || strings.Contains(projectQuery[0].SQL, "task_assignments") {
+				t.Errorf("riwayat project tidak boleh difilter assignee: %v", projectQuery)
+			}
+		})
+	}
+}
+
+func hasArg(args []driver.NamedValue, want interface{}) bool {
+	for _, arg := range args {
+		if arg.Value == want {
+			return true
+		}
+	}
+	return false
+}
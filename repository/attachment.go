@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"PA/models"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func GetTaskAttachments(db *gorm.DB, taskID uint) ([]models.Attachment, error) {
+	var attachments []models.Attachment
+	err := db.Where("task_id = ?", taskID).Order("created_at, id").Find(&attachments).Error
+	return attachments, err
+}
+
+func GetAttachment(db *gorm.DB, taskID, attachmentID uint) (models.Attachment, error) {
+	var attachment models.Attachment
+	err := db.Where("task_id = ?", taskID).First(&attachment, attachmentID).Error
+	return attachment, err
+}
+
+// lockBlob mengambil advisory lock per checksum sampai transaksi selesai. Key diambil dari 15 digit hex
+// pertama checksum sehingga muat di bigint.
+func lockBlob(tx *gorm.DB, checksum string) error {
+	key, err := strconv.ParseInt(checksum[:15], 16, 64)
+	if err != nil {
+		return err
+	}
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", key).Error
+}
+
+// TouchBlob memperbarui last_used_at blob dan mengembalikan false jika blob belum tersimpan.
+// Blob yang baru disentuh tidak akan dihapus cleanup selama masa tenggang. Lock yang sama dengan
+// DeleteOrphanBlob membuat TouchBlob menunggu sampai file blob yang sedang di-purge benar-benar terhapus,
+// sehingga upload yang mendapat false akan menulis ulang file setelahnya, bukan sebelumnya.
+func TouchBlob(db *gorm.DB, checksum string) (bool, error) {
+	touched := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBlob(tx, checksum); err != nil {
+			return err
+		}
+		result := tx.Model(&models.AttachmentBlob{}).Where("checksum = ?", checksum).Update("last_used_at", time.Now())
+		touched = result.RowsAffected > 0
+		return result.Error
+	})
+	return touched, err
+}
+
+// CreateAttachment mencatat blob (jika belum ada) dan attachment dalam satu transaksi
+func CreateAttachment(db *gorm.DB, attachment *models.Attachment) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		blob := models.AttachmentBlob{
+			Checksum:    attachment.Checksum,
+			Size:        attachment.Size,
+			ContentType: attachment.ContentType,
+			LastUsedAt:  time.Now(),
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "checksum"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_used_at"}),
+		}).Create(&blob).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(attachment).Error
+	})
+}
+
+func DeleteAttachment(db *gorm.DB, attachmentID uint) error {
+	return db.Delete(&models.Attachment{}, attachmentID).Error
+}
+
+// GetOrphanBlobs mengembalikan checksum blob yang tidak dipakai attachment mana pun dan tidak disentuh sejak cutoff
+func GetOrphanBlobs(db *gorm.DB, cutoff time.Time) ([]string, error) {
+	var checksums []string
+	err := db.Model(&models.AttachmentBlob{}).
+		Where("last_used_at < ?", cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM attachments WHERE attachments.checksum = attachment_blobs.checksum)").
+		Pluck("checksum", &checksums).Error
+	return checksums, err
+}
+
+// DeleteOrphanBlob menghapus catatan blob jika masih belum dipakai lalu menjalankan deleteFile, keduanya
+// di bawah lock per checksum yang juga diambil TouchBlob. Jika deleteFile gagal catatan blob dikembalikan
+// agar dicoba lagi pada cleanup berikutnya.
+func DeleteOrphanBlob(db *gorm.DB, checksum string, cutoff time.Time, deleteFile func() error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := lockBlob(tx, checksum); err != nil {
+			return err
+		}
+		result := tx.Where("checksum = ? AND last_used_at < ?", checksum, cutoff).
+			Where("NOT EXISTS (SELECT 1 FROM attachments WHERE attachments.checksum = attachment_blobs.checksum)").
+			Delete(&models.AttachmentBlob{})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return deleteFile()
+	})
+}
+
+func deleteTaskAttachments(db *gorm.DB, taskIDs []uint) error {
+	return db.Where("task_id IN ?", taskIDs).Delete(&models.Attachment{}).Error
+}
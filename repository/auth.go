@@ -20,4 +20,22 @@ func GetUserByEmail(db *gorm.DB, email string) (models.User, error) {
 
 func CreateUser(db *gorm.DB, user models.User) error {
 	return db.Create(&user).Error
-}
\ No newline at end of file
+}
+
+// SyncAdmins menjadikan user yang username atau email-nya ada di identifiers sebagai admin sistem
+// dan mencabut status admin user lainnya
+func SyncAdmins(db *gorm.DB, identifiers []string) error {
+    if len(identifiers) == 0 {
+        return db.Model(&models.User{}).Where("is_admin = ?", true).Update("is_admin", false).Error
+    }
+    isAdmin := gorm.Expr("(username IN ? OR email IN ?)", identifiers, identifiers)
+    return db.Model(&models.User{}).
+        Where("is_admin <> ?", isAdmin).
+        Update("is_admin", isAdmin).Error
+}
+
+func GetUserByID(db *gorm.DB, id uint) (models.User, error) {
+    var user models.User
+    err := db.First(&user, id).Error
+    return user, err
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"PA/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CommentSortKeys adalah kolom sort yang didukung untuk daftar comment
+var CommentSortKeys = map[string]SortKey{
+	"created": {Column: "created_at", IsTime: true},
+}
+
+func preloadComment(db *gorm.DB) *gorm.DB {
+	return db.Preload("Author", selectUser).Preload("Mentions.User", selectUser)
+}
+
+func GetTaskComments(db *gorm.DB, taskID uint, opts models.ListOptions) ([]models.TaskComment, string, error) {
+	opts, key, err := normalizeSort(opts, CommentSortKeys)
+	if err != nil {
+		return nil, "", err
+	}
+	paginate, err := Paginate("task_comments", key, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var comments []models.TaskComment
+	err = db.Scopes(preloadComment, paginate).
+		Where("task_comments.task_id = ?", taskID).
+		Find(&comments).Error
+	if err != nil {
+		return nil, "", err
+	}
+
+	n, next := nextCursor(len(comments), opts, func(i int) interface{} { return comments[i].CreatedAt }, func(i int) uint { return comments[i].ID })
+	return comments[:n], next, nil
+}
+
+func GetCommentByID(db *gorm.DB, taskID, commentID uint) (models.TaskComment, error) {
+	var comment models.TaskComment
+	err := db.Scopes(preloadComment).
+		Where("task_id = ?", taskID).
+		First(&comment, commentID).Error
+	return comment, err
+}
+
+// CreateComment menyimpan comment beserta user yang di-mention
+func CreateComment(db *gorm.DB, comment *models.TaskComment, mentionIDs []uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Author", "Mentions").Create(comment).Error; err != nil {
+			return err
+		}
+		if err := saveMentions(tx, comment.ID, mentionIDs); err != nil {
+			return err
+		}
+		return tx.Scopes(preloadComment).First(comment, comment.ID).Error
+	})
+}
+
+// UpdateComment menyimpan isi lama ke riwayat edit, mengganti isi comment dan daftar mention
+func UpdateComment(db *gorm.DB, comment *models.TaskComment, body string, editorID uint, mentionIDs []uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		edit := models.TaskCommentEdit{
+			CommentID:  comment.ID,
+			Body:       comment.Body,
+			EditedByID: editorID,
+			EditedAt:   now,
+		}
+		if err := tx.Create(&edit).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&models.TaskComment{}).Where("id = ?", comment.ID).
+			Updates(map[string]interface{}{"body": body, "edited_at": now, "updated_at": now}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&models.CommentMention{}).Error; err != nil {
+			return err
+		}
+		if err := saveMentions(tx, comment.ID, mentionIDs); err != nil {
+			return err
+		}
+		return tx.Scopes(preloadComment).First(comment, comment.ID).Error
+	})
+}
+
+func saveMentions(db *gorm.DB, commentID uint, userIDs []uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	mentions := make([]models.CommentMention, len(userIDs))
+	for i, userID := range userIDs {
+		mentions[i] = models.CommentMention{CommentID: commentID, UserID: userID}
+	}
+	return db.Omit("User").Create(&mentions).Error
+}
+
+// DeleteComment melakukan soft delete dan mencatat siapa yang menghapus
+func DeleteComment(db *gorm.DB, commentID, userID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.TaskComment{}).Where("id = ?", commentID).Update("deleted_by_id", userID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.TaskComment{}, commentID).Error
+	})
+}
+
+func GetCommentEdits(db *gorm.DB, commentID uint) ([]models.TaskCommentEdit, error) {
+	var edits []models.TaskCommentEdit
+	err := db.Where("comment_id = ?", commentID).Order("edited_at DESC").Find(&edits).Error
+	return edits, err
+}
+
+// deleteTaskComments menghapus permanen seluruh comment (termasuk yang sudah di-soft delete),
+// mention dan riwayat edit milik task
+func deleteTaskComments(db *gorm.DB, taskIDs []uint) error {
+	commentIDs := db.Unscoped().Model(&models.TaskComment{}).Select("id").Where("task_id IN ?", taskIDs)
+	if err := db.Where("comment_id IN (?)", commentIDs).Delete(&models.CommentMention{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("comment_id IN (?)", commentIDs).Delete(&models.TaskCommentEdit{}).Error; err != nil {
+		return err
+	}
+	return db.Unscoped().Where("task_id IN ?", taskIDs).Delete(&models.TaskComment{}).Error
+}
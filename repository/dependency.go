@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"PA/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// preloadLinkedTask memuat data yang dibutuhkan policy untuk mengecek akses ke task di ujung dependency
+func preloadLinkedTask(name string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Preload(name + ".Assignments").Preload(name + ".Project.Collaborators")
+	}
+}
+
+var (
+	ErrDependencyExists = errors.New("dependency already exists")
+	ErrDependencyCycle  = errors.New("dependency creates a cycle")
+)
+
+// dependencyLockKey adalah kunci advisory lock untuk perubahan graph dependency. Dependency boleh
+// lintas project, jadi dipakai satu kunci global, bukan kunci per project.
+const dependencyLockKey = 7301
+
+// CreateDependency menyimpan link blocker -> blocked. Pengecekan duplikat, pengecekan siklus dan insert
+// dijalankan dalam satu transaksi di bawah advisory lock, sehingga dua insert paralel (A -> B dan B -> A)
+// tidak bisa sama-sama lolos pengecekan siklus.
+func CreateDependency(db *gorm.DB, dependency *models.TaskDependency) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", dependencyLockKey).Error; err != nil {
+			return err
+		}
+		exists, err := DependencyExists(tx, dependency.BlockerID, dependency.BlockedID)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrDependencyExists
+		}
+		cycle, err := DependencyCreatesCycle(tx, dependency.BlockerID, dependency.BlockedID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrDependencyCycle
+		}
+		return tx.Omit("Blocker", "Blocked").Create(dependency).Error
+	})
+}
+
+func DependencyExists(db *gorm.DB, blockerID, blockedID uint) (bool, error) {
+	var count int64
+	err := db.Model(&models.TaskDependency{}).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// DependencyCreatesCycle mengecek apakah blocked (secara langsung maupun tidak langsung) sudah memblokir blocker,
+// sehingga link blocker -> blocked akan membentuk siklus
+func DependencyCreatesCycle(db *gorm.DB, blockerID, blockedID uint) (bool, error) {
+	var count int64
+	err := db.Raw(`
+		WITH RECURSIVE reach AS (
+			SELECT blocked_id AS id FROM task_dependencies WHERE blocker_id = ?
+			UNION
+			SELECT d.blocked_id FROM task_dependencies d JOIN reach ON d.blocker_id = reach.id
+		)
+		SELECT COUNT(*) FROM reach WHERE id = ?`, blockedID, blockerID).Scan(&count).Error
+	return count > 0, err
+}
+
+func GetDependency(db *gorm.DB, taskID, dependencyID uint) (models.TaskDependency, error) {
+	var dependency models.TaskDependency
+	err := db.Where("(blocker_id = ? OR blocked_id = ?)", taskID, taskID).First(&dependency, dependencyID).Error
+	return dependency, err
+}
+
+func DeleteDependency(db *gorm.DB, dependencyID uint) error {
+	return db.Delete(&models.TaskDependency{}, dependencyID).Error
+}
+
+// GetTaskDependencies mengembalikan dependency di mana task menjadi blocker (blocks) dan yang memblokir task (blockedBy)
+func GetTaskDependencies(db *gorm.DB, taskID uint) ([]models.TaskDependency, []models.TaskDependency, error) {
+	var blocks, blockedBy []models.TaskDependency
+	err := db.Scopes(preloadLinkedTask("Blocked")).
+		Where("blocker_id = ? AND blocked_id IN (?)", taskID, db.Model(&models.Task{}).Select("id")).
+		Order("id").
+		Find(&blocks).Error
+	if err != nil {
+		return nil, nil, err
+	}
+	err = db.Scopes(preloadLinkedTask("Blocker")).
+		Where("blocked_id = ? AND blocker_id IN (?)", taskID, db.Model(&models.Task{}).Select("id")).
+		Order("id").
+		Find(&blockedBy).Error
+	return blocks, blockedBy, err
+}
+
+// GetOpenBlockers mengembalikan dependency yang task blocker-nya belum berada di status kategori done
+// menurut workflow project blocker tersebut
+func GetOpenBlockers(db *gorm.DB, taskID uint) ([]models.TaskDependency, error) {
+	var dependencies []models.TaskDependency
+	err := db.Scopes(preloadLinkedTask("Blocker")).
+		Joins("JOIN tasks ON tasks.id = task_dependencies.blocker_id AND tasks.deleted_at IS NULL").
+		Joins("LEFT JOIN workflow_statuses ON workflow_statuses.project_id = tasks.project_id AND LOWER(workflow_statuses.name) = LOWER(tasks.status)").
+		Where("task_dependencies.blocked_id = ?", taskID).
+		Where("(workflow_statuses.category IS NULL OR workflow_statuses.category <> ?)", models.StatusCategoryDone).
+		Order("task_dependencies.id").
+		Find(&dependencies).Error
+	return dependencies, err
+}
+
+func deleteTaskDependencies(db *gorm.DB, taskIDs []uint) error {
+	return db.Where("(blocker_id IN ? OR blocked_id IN ?)", taskIDs, taskIDs).Delete(&models.TaskDependency{}).Error
+}
+
+// GetProjectDependencies mengembalikan dependency yang kedua task-nya berada di project yang sama
+func GetProjectDependencies(db *gorm.DB, projectID uint) ([]models.TaskDependency, error) {
+	var dependencies []models.TaskDependency
+	projectTasks := db.Model(&models.Task{}).Select("id").Where("project_id = ?", projectID)
+	err := db.Where("blocker_id IN (?) AND blocked_id IN (?)", projectTasks, projectTasks).
+		Order("id").
+		Find(&dependencies).Error
+	return dependencies, err
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"PA/models"
+	"reflect"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// EventSortKeys adalah kolom sort yang didukung untuk riwayat task
+var EventSortKeys = map[string]SortKey{
+	"created": {Column: "created_at", IsTime: true},
+}
+
+// trackedTaskFields adalah kolom task yang perubahannya dicatat di riwayat, nama kolom sama dengan nama field JSON
+var trackedTaskFields = []string{"title", "description", "status", "deadline", "duration_days", "story_points", "estimated_hours", "parent_id", "sprint_id"}
+
+// taskFieldValue mengembalikan nilai kolom task dengan pointer yang sudah di-dereference agar mudah dibandingkan
+func taskFieldValue(task *models.Task, field string) interface{} {
+	switch field {
+	case "title":
+		return task.Title
+	case "description":
+		return task.Description
+	case "status":
+		return task.Status
+	case "deadline":
+		return task.Deadline.UTC()
+	case "duration_days":
+		return task.DurationDays
+	case "story_points":
+		if task.StoryPoints != nil {
+			return *task.StoryPoints
+		}
+	case "estimated_hours":
+		if task.EstimatedHours != nil {
+			return *task.EstimatedHours
+		}
+	case "parent_id":
+		if task.ParentID != nil {
+			return *task.ParentID
+		}
+	case "sprint_id":
+		if task.SprintID != nil {
+			return *task.SprintID
+		}
+	}
+	return nil
+}
+
+func sameValue(a, b interface{}) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// assigneeIDs mengembalikan id assignee task yang sudah diurutkan
+func assigneeIDs(assignments []models.TaskAssignment) []uint {
+	ids := make([]uint, 0, len(assignments))
+	for _, assignment := range assignments {
+		ids = append(ids, assignment.UserID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// taskSnapshot berisi seluruh field yang dicatat, dipakai sebagai nilai after saat task dibuat
+// atau nilai before saat task dihapus
+func taskSnapshot(task *models.Task, deleted bool) models.FieldChanges {
+	changes := models.FieldChanges{}
+	for _, field := range trackedTaskFields {
+		value := taskFieldValue(task, field)
+		if deleted {
+			changes[field] = models.FieldChange{Before: value}
+		} else {
+			changes[field] = models.FieldChange{After: value}
+		}
+	}
+	assignees := assigneeIDs(task.Assignments)
+	if deleted {
+		changes["assigned_to"] = models.FieldChange{Before: assignees}
+	} else {
+		changes["assigned_to"] = models.FieldChange{After: assignees}
+	}
+	return changes
+}
+
+// diffTask membandingkan field yang disebut di fields antara task lama dan baru.
+// Assignee hanya dibandingkan jika compareAssignees true.
+func diffTask(previous, task *models.Task, fields []string, compareAssignees bool) models.FieldChanges {
+	changes := models.FieldChanges{}
+	for _, field := range trackedTaskFields {
+		if !hasField(fields, field) {
+			continue
+		}
+		before, after := taskFieldValue(previous, field), taskFieldValue(task, field)
+		if !sameValue(before, after) {
+			changes[field] = models.FieldChange{Before: before, After: after}
+		}
+	}
+	if compareAssignees {
+		before, after := assigneeIDs(previous.Assignments), assigneeIDs(task.Assignments)
+		if !reflect.DeepEqual(before, after) {
+			changes["assigned_to"] = models.FieldChange{Before: before, After: after}
+		}
+	}
+	return changes
+}
+
+// recordTaskEvent menambahkan event ke riwayat task. Perubahan kosong tidak dicatat.
+func recordTaskEvent(db *gorm.DB, task *models.Task, action string, changes models.FieldChanges, actorID uint) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	event := models.TaskEvent{
+		TaskID:    task.ID,
+		ProjectID: task.ProjectID,
+		Action:    action,
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}
+	if actorID != 0 {
+		event.ActorID = &actorID
+	}
+	return db.Create(&event).Error
+}
+
+// updateEventAction memakai aksi assignees_changed jika yang berubah hanya assignee
+func updateEventAction(changes models.FieldChanges) string {
+	if _, ok := changes["assigned_to"]; ok && len(changes) == 1 {
+		return models.TaskEventAssigned
+	}
+	return models.TaskEventUpdated
+}
+
+// GetTaskEvents mengembalikan riwayat task dengan keyset pagination
+func GetTaskEvents(db *gorm.DB, taskID uint, opts models.ListOptions) ([]models.TaskEvent, string, error) {
+	opts, key, err := normalizeSort(opts, EventSortKeys)
+	if err != nil {
+		return nil, "", err
+	}
+	paginate, err := Paginate("task_events", key, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var events []models.TaskEvent
+	err = db.Scopes(paginate).
+		Preload("Actor", selectUser).
+		Where("task_events.task_id = ?", taskID).
+		Find(&events).Error
+	if err != nil {
+		return nil, "", err
+	}
+
+	n, next := nextCursor(len(events), opts, func(i int) interface{} { return events[i].CreatedAt }, func(i int) uint { return events[i].ID })
+	return events[:n], next, nil
+}
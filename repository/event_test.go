@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"PA/internal/fakedb"
+	"PA/models"
+)
+
+func TestDiffTask(t *testing.T) {
+	sprint := uint(7)
+	points := 3
+	deadline := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	previous := models.Task{
+		Title:       "Login",
+		Status:      "todo",
+		Deadline:    deadline,
+		Assignments: []models.TaskAssignment{{UserID: 2}, {UserID: 1}},
+	}
+
+	tests := []struct {
+		name             string
+		change           func(task *models.Task)
+		fields           []string
+		compareAssignees bool
+		want             models.FieldChanges
+		action           string
+	}{
+		{
+			name:   "field berubah",
+			change: func(task *models.Task) { task.Title = "Login SSO"; task.Status = "done" },
+			fields: []string{"title", "status"},
+			want: models.FieldChanges{
+				"title":  {Before: "Login", After: "Login SSO"},
+				"status": {Before: "todo", After: "done"},
+			},
+			action: models.TaskEventUpdated,
+		},
+		{
+			name:   "field yang tidak disebut diabaikan",
+			change: func(task *models.Task) { task.Title = "Login SSO" },
+			fields: []string{"status"},
+			want:   models.FieldChanges{},
+			action: models.TaskEventUpdated,
+		},
+		{
+			name:   "nilai sama tidak dicatat",
+			change: func(task *models.Task) { task.Deadline = deadline.In(time.FixedZone("WIB", 7*3600)) },
+			fields: []string{"deadline", "title"},
+			want:   models.FieldChanges{},
+			action: models.TaskEventUpdated,
+		},
+		{
+			name:   "pointer di-dereference",
+			change: func(task *models.Task) { task.SprintID = &sprint; task.StoryPoints = &points },
+			fields: []string{"sprint_id", "story_points"},
+			want: models.FieldChanges{
+				"sprint_id":    {Before: nil, After: sprint},
+				"story_points": {Before: nil, After: points},
+			},
+			action: models.TaskEventUpdated,
+		},
+		{
+			name:             "hanya assignee berubah",
+			change:           func(task *models.Task) { task.Assignments = []models.TaskAssignment{{UserID: 3}, {UserID: 1}} },
+			compareAssignees: true,
+			want: models.FieldChanges{
+				"assigned_to": {Before: []uint{1, 2}, After: []uint{1, 3}},
+			},
+			action: models.TaskEventAssigned,
+		},
+		{
+			name:             "urutan assignee tidak dianggap perubahan",
+			change:           func(task *models.Task) { task.Assignments = []models.TaskAssignment{{UserID: 1}, {UserID: 2}} },
+			compareAssignees: true,
+			want:             models.FieldChanges{},
+			action:           models.TaskEventUpdated,
+		},
+		{
+			name: "assignee dan field berubah",
+			change: func(task *models.Task) {
+				task.Title = "Login SSO"
+				task.Assignments = []models.TaskAssignment{{UserID: 1}}
+			},
+			fields:           []string{"title"},
+			compareAssignees: true,
+			want: models.FieldChanges{
+				"title":       {Before: "Login", After: "Login SSO"},
+				"assigned_to": {Before: []uint{1, 2}, After: []uint{1}},
+			},
+			action: models.TaskEventUpdated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := previous
+			task.Assignments = append([]models.TaskAssignment(nil), previous.Assignments...)
+			tt.change(&task)
+
+			got := diffTask(&previous, &task, tt.fields, tt.compareAssignees)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffTask() = %#v, want %#v", got, tt.want)
+			}
+			if action := updateEventAction(got); action != tt.action {
+				t.Errorf("updateEventAction() = %q, want %q", action, tt.action)
+			}
+		})
+	}
+}
+
+// taskRow membuat baris tabel tasks untuk fakeDB
+func taskRow(title, status string, version int64) fakedb.Result {
+	return fakedb.Result{
+		Columns: []string{"id", "project_id", "title", "status", "version"},
+		Rows:    [][]driver.Value{{int64(10), int64(3), title, status, version}},
+	}
+}
+
+func TestUpdateTaskRecordsOneEventInTransaction(t *testing.T) {
+	selects := 0
+	db, fake := fakedb.Open(t, func(query string, _ []driver.NamedValue) fakedb.Result {
+		switch {
+		case strings.HasPrefix(query, `SELECT * FROM "tasks"`):
+			// select pertama adalah nilai lama, select berikutnya adalah reload setelah update
+			selects++
+			if selects == 1 {
+				return taskRow("Login", "todo", 4)
+			}
+			return taskRow("Login SSO", "todo", 5)
+		case strings.HasPrefix(query, `UPDATE "tasks"`):
+			return fakedb.Result{RowsAffected: 1}
+		case strings.HasPrefix(query, `SELECT * FROM "projects"`):
+			return fakedb.Result{Columns: []string{"id", "owner_id"}, Rows: [][]driver.Value{{int64(3), int64(1)}}}
+		case strings.HasPrefix(query, `INSERT INTO "task_events"`):
+			return fakedb.Result{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(99)}}}
+		}
+		return fakedb.Result{}
+	})
+
+	task := models.Task{ID: 10, ProjectID: 3, Title: "Login SSO", Status: "todo", Version: 4}
+	if err := UpdateTask(db, &task, []string{"title"}, nil, 1); err != nil {
+		t.Fatalf("UpdateTask: %v", err)
+	}
+	if task.Version != 5 {
+		t.Errorf("version = %d, want 5", task.Version)
+	}
+
+	events := fake.Queries(`INSERT INTO "task_events"`)
+	if len(events) != 1 {
+		t.Fatalf("jumlah insert task_events = %d, want 1", len(events))
+	}
+	begin, update, insert, commit := fake.IndexOf("BEGIN"), fake.IndexOf(`UPDATE "tasks"`), fake.IndexOf(`INSERT INTO "task_events"`), fake.IndexOf("COMMIT")
+	if !(begin >= 0 && begin < update && update < insert && insert < commit) {
+		t.Errorf("urutan statement BEGIN=%d UPDATE=%d INSERT=%d COMMIT=%d, event harus ditulis di transaksi yang sama setelah update", begin, update, insert, commit)
+	}
+	if len(fake.Queries(`INSERT INTO "task_status_changes"`)) != 0 {
+		t.Error("status tidak berubah tetapi riwayat status dicatat")
+	}
+
+	changes, action := eventArgs(t, events[0])
+	if action != models.TaskEventUpdated {
+		t.Errorf("action = %q, want %q", action, models.TaskEventUpdated)
+	}
+	want := models.FieldChanges{"title": {Before: "Login", After: "Login SSO"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %#v, want %#v", changes, want)
+	}
+}
+
+func TestUpdateTaskStaleVersionRecordsNothing(t *testing.T) {
+	db, fake := fakedb.Open(t, func(query string, _ []driver.NamedValue) fakedb.Result {
+		if strings.HasPrefix(query, `SELECT * FROM "tasks"`) {
+			return taskRow("Login", "todo", 5)
+		}
+		return fakedb.Result{}
+	})
+
+	task := models.Task{ID: 10, ProjectID: 3, Title: "Login SSO", Version: 4}
+	err := UpdateTask(db, &task, []string{"title"}, nil, 1)
+	if !errors.Is(err, ErrStaleVersion) {
+		t.Fatalf("err = %v, want ErrStaleVersion", err)
+	}
+	if task.Version != 4 {
+		t.Errorf("version = %d, want tetap 4", task.Version)
+	}
+	if n := len(fake.Queries(`INSERT INTO "task_events"`)); n != 0 {
+		t.Errorf("jumlah insert task_events = %d, want 0", n)
+	}
+	if fake.IndexOf("ROLLBACK") < 0 || fake.IndexOf("COMMIT") >= 0 {
+		t.Error("transaksi harus di-rollback")
+	}
+}
+
+// eventArgs mengambil kolom changes dan action dari statement insert task_events
+func eventArgs(t *testing.T, statement fakedb.Statement) (models.FieldChanges, string) {
+	t.Helper()
+	columns := strings.Split(statement.SQL[strings.Index(statement.SQL, "(")+1:strings.Index(statement.SQL, ")")], ",")
+	var changes models.FieldChanges
+	var action string
+	for i, column := range columns {
+		switch strings.Trim(column, `" `) {
+		case "changes":
+			raw, _ := statement.Args[i].Value.(string)
+			if err := json.Unmarshal([]byte(raw), &changes); err != nil {
+				t.Fatalf("changes bukan JSON: %v", err)
+			}
+		case "action":
+			action, _ = statement.Args[i].Value.(string)
+		}
+	}
+	return changes, action
+}
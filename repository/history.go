@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"PA/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// recordStatusChange mencatat perpindahan status task beserta kategori status tujuannya di workflow saat ini
+func recordStatusChange(db *gorm.DB, task *models.Task, fromStatus string, changedBy uint) error {
+	var categories []string
+	err := db.Model(&models.WorkflowStatus{}).
+		Where("project_id = ? AND name = ?", task.ProjectID, task.Status).
+		Limit(1).
+		Pluck("category", &categories).Error
+	if err != nil {
+		return err
+	}
+
+	change := models.TaskStatusChange{
+		TaskID:     task.ID,
+		ProjectID:  task.ProjectID,
+		FromStatus: fromStatus,
+		ToStatus:   task.Status,
+		ChangedAt:  time.Now(),
+	}
+	if len(categories) > 0 {
+		change.ToCategory = categories[0]
+	}
+	if changedBy != 0 {
+		change.ChangedByID = &changedBy
+	}
+	return db.Create(&change).Error
+}
+
+// GetStatusChanges mengembalikan riwayat status task project sebelum waktu tertentu, diurutkan per task
+// dan waktu. taskIDs nil berarti semua task project yang tidak ada di trash.
+func GetStatusChanges(db *gorm.DB, projectID uint, taskIDs []uint, before time.Time) ([]models.TaskStatusChange, error) {
+	var changes []models.TaskStatusChange
+	query := db.Where("project_id = ? AND changed_at < ?", projectID, before)
+	if taskIDs != nil {
+		query = query.Where("task_id IN ?", taskIDs)
+	} else {
+		query = query.Where("task_id IN (?)", db.Model(&models.Task{}).Select("id").Where("project_id = ?", projectID))
+	}
+	err := query.Order("task_id, changed_at, id").Find(&changes).Error
+	return changes, err
+}
+
+// renameStatusHistory menyamakan nama status di riwayat saat status workflow diganti namanya
+func renameStatusHistory(db *gorm.DB, projectID uint, renames map[string]string) error {
+	for _, column := range []string{"from_status", "to_status"} {
+		expr, olds := renameCase(column, renames)
+		err := db.Model(&models.TaskStatusChange{}).
+			Where("project_id = ? AND "+column+" IN ?", projectID, olds).
+			UpdateColumn(column, expr).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteTaskStatusChanges(db *gorm.DB, taskIDs []uint) error {
+	return db.Where("task_id IN ?", taskIDs).Delete(&models.TaskStatusChange{}).Error
+}
+
+// GetCompletedTasks mengembalikan task project yang selesai (completed_at) di [from, to) beserta assignee-nya
+func GetCompletedTasks(db *gorm.DB, projectID uint, from, to time.Time) ([]models.Task, error) {
+	var tasks []models.Task
+	err := db.Preload("Assignments.User").
+		Where("project_id = ? AND completed_at >= ? AND completed_at < ?", projectID, from, to).
+		Order("id").
+		Find(&tasks).Error
+	return tasks, err
+}
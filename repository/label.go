@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"PA/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func GetProjectLabels(db *gorm.DB, projectID uint) ([]models.Label, error) {
+	var labels []models.Label
+	err := db.Where("project_id = ?", projectID).Order("name").Find(&labels).Error
+	return labels, err
+}
+
+func GetLabel(db *gorm.DB, projectID, labelID uint) (models.Label, error) {
+	var label models.Label
+	err := db.Where("project_id = ?", projectID).First(&label, labelID).Error
+	return label, err
+}
+
+func CreateLabel(db *gorm.DB, label *models.Label) error {
+	return db.Create(label).Error
+}
+
+// DeleteLabel menghapus label beserta pemakaiannya di task
+func DeleteLabel(db *gorm.DB, labelID uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("label_id = ?", labelID).Delete(&models.TaskLabel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Label{}, labelID).Error
+	})
+}
+
+// AddTaskLabel memasang label ke task, tidak error jika label sudah terpasang
+func AddTaskLabel(db *gorm.DB, taskID, labelID uint) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.TaskLabel{TaskID: taskID, LabelID: labelID}).Error
+}
+
+func RemoveTaskLabel(db *gorm.DB, taskID, labelID uint) error {
+	result := db.Where("task_id = ? AND label_id = ?", taskID, labelID).Delete(&models.TaskLabel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func GetTaskLabels(db *gorm.DB, taskID uint) ([]models.Label, error) {
+	var labels []models.Label
+	err := db.Joins("JOIN task_labels ON task_labels.label_id = labels.id").
+		Where("task_labels.task_id = ?", taskID).
+		Order("labels.name").
+		Find(&labels).Error
+	return labels, err
+}
+
+// GetLabelsByTask mengembalikan label beberapa task sekaligus, dikelompokkan per task
+func GetLabelsByTask(db *gorm.DB, taskIDs []uint) (map[uint][]models.Label, error) {
+	result := make(map[uint][]models.Label)
+	if len(taskIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		TaskID uint
+		models.Label
+	}
+	err := db.Table("labels").
+		Select("task_labels.task_id, labels.*").
+		Joins("JOIN task_labels ON task_labels.label_id = labels.id").
+		Where("task_labels.task_id IN ?", taskIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		result[row.TaskID] = append(result[row.TaskID], row.Label)
+	}
+	return result, nil
+}
+
+func deleteTaskLabels(db *gorm.DB, taskIDs []uint) error {
+	return db.Where("task_id IN ?", taskIDs).Delete(&models.TaskLabel{}).Error
+}
+
+// deleteProjectLabels dipanggil saat project dihapus, setelah task_labels milik task project dibersihkan
+func deleteProjectLabels(db *gorm.DB, projectID uint) error {
+	return db.Where("project_id = ?", projectID).Delete(&models.Label{}).Error
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"PA/models"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidSort   = errors.New("invalid sort key")
+)
+
+// DefaultSort dipakai jika parameter sort tidak dikirim
+const DefaultSort = "created"
+
+// SortKey adalah kolom yang boleh dipakai untuk sorting sebuah list
+type SortKey struct {
+	Column string
+	IsTime bool
+}
+
+// cursor menyimpan nilai kolom sort dan id dari baris terakhir halaman sebelumnya
+type cursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+	Kind  string `json:"k,omitempty"` // sumber baris untuk list gabungan beberapa tabel
+}
+
+func pageLimit(o models.ListOptions) int {
+	if o.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	if o.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return o.Limit
+}
+
+// normalizeSort mengisi sort default dan memastikan key sort didukung
+func normalizeSort(opts models.ListOptions, keys map[string]SortKey) (models.ListOptions, SortKey, error) {
+	if opts.Sort == "" {
+		opts.Sort = DefaultSort
+	}
+	key, ok := keys[opts.Sort]
+	if !ok {
+		return opts, SortKey{}, ErrInvalidSort
+	}
+	return opts, key, nil
+}
+
+func encodeCursor(c cursor) string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (cursor, error) {
+	var c cursor
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return c, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Paginate adalah scope keyset pagination: ORDER BY kolom sort lalu id, dan hanya mengambil baris
+// setelah cursor. Mengambil limit+1 baris supaya pemanggil tahu masih ada halaman berikutnya.
+func Paginate(table string, key SortKey, opts models.ListOptions) (func(*gorm.DB) *gorm.DB, error) {
+	column := table + "." + key.Column
+	idColumn := table + ".id"
+	direction := "ASC"
+	operator := ">"
+	if opts.Desc {
+		direction = "DESC"
+		operator = "<"
+	}
+
+	var after *cursor
+	if opts.Cursor != "" {
+		c, err := decodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if c.Sort != opts.Sort || c.Desc != opts.Desc {
+			return nil, ErrInvalidCursor
+		}
+		after = &c
+	}
+
+	var value interface{}
+	if after != nil {
+		value = after.Value
+		if key.IsTime {
+			parsed, err := time.Parse(time.RFC3339Nano, after.Value)
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			value = parsed
+		}
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		if after != nil {
+			db = db.Where("("+column+" "+operator+" ? OR ("+column+" = ? AND "+idColumn+" "+operator+" ?))", value, value, after.ID)
+		}
+		return db.Order(column + " " + direction).Order(idColumn + " " + direction).Limit(pageLimit(opts) + 1)
+	}, nil
+}
+
+// nextCursor memotong hasil ke limit dan membuat cursor dari baris terakhir jika masih ada halaman berikutnya.
+// sortValue dan id mengambil nilai kolom sort dan id dari baris ke-i.
+func nextCursor(count int, opts models.ListOptions, sortValue func(i int) interface{}, id func(i int) uint) (int, string) {
+	limit := pageLimit(opts)
+	if count <= limit {
+		return count, ""
+	}
+
+	last := limit - 1
+	value := ""
+	switch v := sortValue(last).(type) {
+	case time.Time:
+		value = v.UTC().Format(time.RFC3339Nano)
+	case string:
+		value = v
+	case uint:
+		value = strconv.FormatUint(uint64(v), 10)
+	}
+
+	return limit, encodeCursor(cursor{Sort: opts.Sort, Desc: opts.Desc, Value: value, ID: id(last)})
+}
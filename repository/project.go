@@ -3,22 +3,82 @@ package repository
 import (
 	"PA/models"
 	"errors"
+	"time"
 	"gorm.io/gorm"
 )
 
-func GetAllProjects(db *gorm.DB, userID uint) ([]models.Project, error) {
+func GetAllProjects(db *gorm.DB, userID uint, filter models.ProjectFilter, opts models.ListOptions) ([]models.Project, string, error) {
+	opts, key, err := normalizeSort(opts, ProjectSortKeys)
+	if err != nil {
+		return nil, "", err
+	}
+	paginate, err := Paginate("projects", key, opts)
+	if err != nil {
+		return nil, "", err
+	}
+
 	var projects []models.Project
 
-	err := db.Preload("Collaborators.User", func(db *gorm.DB) *gorm.DB {
+	err = db.Preload("Collaborators.User", func(db *gorm.DB) *gorm.DB {
         return db.Select("id, username, email")
     }).
-    Where("owner_id = ?", userID).
+    Scopes(ProjectMembership(userID, filter.Membership), ProjectArchived(filter.Archived), ProjectSearch(filter.Query), paginate).
     Find(&projects).Error
 
     if err != nil {
-        return nil, err
+        return nil, "", err
     }
-    return projects, nil
+
+    n, next := nextCursor(len(projects), opts, func(i int) interface{} { return projectSortValue(projects[i], opts.Sort) }, func(i int) uint { return projects[i].ID })
+    return projects[:n], next, nil
+}
+
+func projectSortValue(project models.Project, sort string) interface{} {
+	if sort == "name" {
+		return project.Name
+	}
+	return project.CreatedAt
+}
+
+// CountTasksByProject menghitung jumlah task per kategori status untuk beberapa project sekaligus
+// dalam satu query agregat. Task dengan status di luar workflow hanya masuk ke Total.
+func CountTasksByProject(db *gorm.DB, projectIDs []uint) (map[uint]models.TaskSummary, error) {
+	summaries := make(map[uint]models.TaskSummary, len(projectIDs))
+	if len(projectIDs) == 0 {
+		return summaries, nil
+	}
+
+	var rows []struct {
+		ProjectID uint
+		Category  *string
+		Count     int64
+	}
+	err := db.Table("tasks").
+		Select("tasks.project_id, workflow_statuses.category, COUNT(*) AS count").
+		Joins("LEFT JOIN workflow_statuses ON workflow_statuses.project_id = tasks.project_id AND LOWER(workflow_statuses.name) = LOWER(tasks.status)").
+		Where("tasks.project_id IN ? AND tasks.deleted_at IS NULL", projectIDs).
+		Group("tasks.project_id, workflow_statuses.category").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		summary := summaries[row.ProjectID]
+		summary.Total += row.Count
+		if row.Category != nil {
+			switch *row.Category {
+			case models.StatusCategoryTodo:
+				summary.Todo += row.Count
+			case models.StatusCategoryInProgress:
+				summary.InProgress += row.Count
+			case models.StatusCategoryDone:
+				summary.Done += row.Count
+			}
+		}
+		summaries[row.ProjectID] = summary
+	}
+	return summaries, nil
 }
 
 func GetProjectByID(db *gorm.DB, projectID uint, userID uint) (models.Project, error) {
@@ -31,72 +91,245 @@ func GetProjectByID(db *gorm.DB, projectID uint, userID uint) (models.Project, e
 	return project, err
 }
 
+// GetProjectMembers mengembalikan owner dan seluruh collaborator project
+func GetProjectMembers(db *gorm.DB, projectID uint) ([]models.User, error) {
+	var users []models.User
+	err := db.Select("id, username, email").
+		Where("id = (SELECT owner_id FROM projects WHERE id = ?) OR id IN (SELECT user_id FROM project_collaborators WHERE project_id = ?)", projectID, projectID).
+		Find(&users).Error
+	return users, err
+}
+
 func CreateProject(db *gorm.DB, project *models.Project) error {
-	return db.Create(project).Error
+	project.Version = 1
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(project).Error; err != nil {
+			return err
+		}
+		return CreateDefaultWorkflow(tx, project.ID)
+	})
 }
 
-func UpdateProject(db *gorm.DB, project *models.Project, userID uint) error {
-    result := db.Model(&models.Project{}).
-        Where("id = ? AND owner_id = ?", project.ID, userID).
-        Updates(project)
-        
-    if result.Error != nil {
-        return result.Error
+// ErrStaleVersion dikembalikan saat compare-and-swap version gagal karena data sudah diubah
+var ErrStaleVersion = errors.New("stale version")
+
+// UpdateProject melakukan UPDATE ... WHERE id = ? AND version = ? dengan version yang terakhir dibaca.
+// Perubahan name dan description dicatat ke riwayat project atas nama updatedBy.
+func UpdateProject(db *gorm.DB, project *models.Project, updatedBy uint) error {
+    expected := project.Version
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var previous models.Project
+        if err := tx.Select("id", "name", "description").First(&previous, project.ID).Error; err != nil {
+            return err
+        }
+
+        project.Version = expected + 1
+        project.UpdatedAt = time.Now()
+        result := tx.Model(project).
+            Where("version = ?", expected).
+            Select("name", "description", "version", "updated_at").
+            Updates(project)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return ErrStaleVersion
+        }
+
+        changes := models.FieldChanges{}
+        if previous.Name != project.Name {
+            changes["name"] = models.FieldChange{Before: previous.Name, After: project.Name}
+        }
+        if previous.Description != project.Description {
+            changes["description"] = models.FieldChange{Before: previous.Description, After: project.Description}
+        }
+        if len(changes) == 0 {
+            return nil
+        }
+        action := models.ProjectEventUpdated
+        if _, ok := changes["name"]; ok {
+            action = models.ProjectEventRenamed
+        }
+        return RecordProjectEvent(tx, models.ProjectEvent{ProjectID: project.ID, Action: action, Changes: changes}, updatedBy)
+    })
+    if err != nil {
+        project.Version = expected
     }
-    if result.RowsAffected == 0 {
-        return errors.New("project not found or unauthorized")
+    return err
+}
+
+// SetProjectArchived mengarsipkan (archivedAt tidak nil) atau mengaktifkan kembali project dengan
+// compare-and-swap terhadap project.Version, lalu mencatatnya ke riwayat project atas nama changedBy.
+func SetProjectArchived(db *gorm.DB, project *models.Project, archivedAt *time.Time, changedBy uint) error {
+    expected := project.Version
+    previous := project.ArchivedAt
+    project.Version = expected + 1
+    project.UpdatedAt = time.Now()
+    project.ArchivedAt = archivedAt
+
+    err := db.Transaction(func(tx *gorm.DB) error {
+        result := tx.Model(project).
+            Where("version = ?", expected).
+            Select("archived_at", "version", "updated_at").
+            Updates(project)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return ErrStaleVersion
+        }
+        action := models.ProjectEventArchived
+        if archivedAt == nil {
+            action = models.ProjectEventUnarchived
+        }
+        return RecordProjectEvent(tx, models.ProjectEvent{ProjectID: project.ID, Action: action}, changedBy)
+    })
+    if err != nil {
+        project.Version = expected
+        project.ArchivedAt = previous
     }
-    return nil
+    return err
 }
 
-func DeleteProject(db *gorm.DB, projectID uint, ownerID uint) error {
+// DeleteProject memindahkan project beserta seluruh task-nya yang belum dihapus ke trash dengan deleted_at
+// yang sama, sehingga RestoreProject memulihkan task tersebut tanpa ikut memulihkan task yang sudah
+// dihapus sebelumnya. Isi project dihapus permanen oleh PurgeTrash.
+func DeleteProject(db *gorm.DB, projectID uint, ownerID uint, version uint, deletedBy uint) error {
     return db.Transaction(func(tx *gorm.DB) error {
-        if err := tx.Where("project_id = ?", projectID).Delete(&models.ProjectCollaborator{}).Error; err != nil {
-            return err
+        columns := trashColumns(deletedBy)
+        result := tx.Model(&models.Project{}).
+            Where("id = ? AND owner_id = ? AND version = ?", projectID, ownerID, version).
+            UpdateColumns(columns)
+        if result.Error != nil {
+            return result.Error
         }
-        
-        var taskIDs []uint
-        if err := tx.Model(&models.Task{}).Where("project_id = ?", projectID).Pluck("id", &taskIDs).Error; err != nil {
-            return err
+        if result.RowsAffected == 0 {
+            return ErrStaleVersion
         }
-        
-        if len(taskIDs) > 0 {
-            if err := tx.Where("task_id IN (?)", taskIDs).Delete(&models.TaskAssignment{}).Error; err != nil {
-                return err
-            }
-            if err := tx.Where("project_id = ?", projectID).Delete(&models.Task{}).Error; err != nil {
-                return err
-            }
+        if err := tx.Model(&models.Task{}).Where("project_id = ?", projectID).UpdateColumns(columns).Error; err != nil {
+            return err
         }
+        return RecordProjectEvent(tx, models.ProjectEvent{ProjectID: projectID, Action: models.ProjectEventDeleted}, deletedBy)
+    })
+}
 
-        result := tx.Where("id = ? AND owner_id = ?", projectID, ownerID).Delete(&models.Project{})
+// TransferProjectOwnership menjadikan newOwnerID owner project dengan compare-and-swap terhadap
+// project.Version. Jika newOwnerID sebelumnya collaborator, baris collaborator-nya dihapus. Owner lama
+// tetap menjadi collaborator dengan previousOwnerRole, atau keluar dari project jika role kosong.
+// Perpindahan dicatat ke riwayat project atas nama transferredBy.
+func TransferProjectOwnership(db *gorm.DB, project *models.Project, newOwnerID uint, previousOwnerRole string, transferredBy uint) error {
+    expected := project.Version
+    previousOwnerID := project.OwnerID
+    project.Version = expected + 1
+    project.UpdatedAt = time.Now()
+    project.OwnerID = newOwnerID
+
+    err := db.Transaction(func(tx *gorm.DB) error {
+        result := tx.Model(project).
+            Where("version = ?", expected).
+            Select("owner_id", "version", "updated_at").
+            Updates(project)
         if result.Error != nil {
             return result.Error
         }
         if result.RowsAffected == 0 {
-            return gorm.ErrRecordNotFound
+            return ErrStaleVersion
+        }
+
+        changes := models.FieldChanges{"owner_id": {Before: previousOwnerID, After: newOwnerID}}
+        var collab models.ProjectCollaborator
+        err := tx.Where("project_id = ? AND user_id = ?", project.ID, newOwnerID).First(&collab).Error
+        if err == nil {
+            if err := tx.Delete(&collab).Error; err != nil {
+                return err
+            }
+            changes["role"] = models.FieldChange{Before: collab.Role, After: models.RoleOwner}
+        } else if !errors.Is(err, gorm.ErrRecordNotFound) {
+            return err
+        }
+
+        if previousOwnerRole != "" {
+            previous := models.ProjectCollaborator{ProjectID: project.ID, UserID: previousOwnerID, Role: previousOwnerRole}
+            if err := tx.Create(&previous).Error; err != nil {
+                return err
+            }
+            changes["previous_owner_role"] = models.FieldChange{Before: models.RoleOwner, After: previousOwnerRole}
         }
-        return nil
+
+        return RecordProjectEvent(tx, models.ProjectEvent{
+            ProjectID: project.ID,
+            Action: models.ProjectEventOwnershipTransferred,
+            TargetUserID: &newOwnerID,
+            Changes: changes,
+        }, transferredBy)
     })
+    if err != nil {
+        project.Version = expected
+        project.OwnerID = previousOwnerID
+    }
+    return err
 }
 
-func InviteCollaborator(db *gorm.DB, projectID, userID uint) error {
+func InviteCollaborator(db *gorm.DB, projectID, userID uint, role string, invitedBy uint) error {
 	collab := models.ProjectCollaborator{
 		ProjectID: projectID,
 		UserID: userID,
+		Role: role,
 	}
-	return db.Create(&collab).Error
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&collab).Error; err != nil {
+			return err
+		}
+		return RecordProjectEvent(tx, models.ProjectEvent{
+			ProjectID: projectID,
+			Action: models.ProjectEventCollaboratorAdded,
+			TargetUserID: &userID,
+			Changes: models.FieldChanges{"role": {After: role}},
+		}, invitedBy)
+	})
 }
 
-func RemoveCollaborator(db *gorm.DB, projectID, userID uint) error {
-    result := db.Where("project_id = ? AND user_id = ?", projectID, userID).Delete(&models.ProjectCollaborator{})
-    if result.Error != nil {
-        return result.Error
-    }
-    if result.RowsAffected == 0 {
-        return gorm.ErrRecordNotFound
-    }
-    return nil
+func UpdateCollaboratorRole(db *gorm.DB, projectID, userID uint, role string, changedBy uint) error {
+    return db.Transaction(func(tx *gorm.DB) error {
+        var collab models.ProjectCollaborator
+        if err := tx.Where("project_id = ? AND user_id = ?", projectID, userID).First(&collab).Error; err != nil {
+            return err
+        }
+        if collab.Role == role {
+            return nil
+        }
+        if err := tx.Model(&collab).Update("role", role).Error; err != nil {
+            return err
+        }
+        return RecordProjectEvent(tx, models.ProjectEvent{
+            ProjectID: projectID,
+            Action: models.ProjectEventRoleChanged,
+            TargetUserID: &userID,
+            Changes: models.FieldChanges{"role": {Before: collab.Role, After: role}},
+        }, changedBy)
+    })
+}
+
+func RemoveCollaborator(db *gorm.DB, projectID, userID uint, removedBy uint) error {
+    return db.Transaction(func(tx *gorm.DB) error {
+        var collab models.ProjectCollaborator
+        if err := tx.Where("project_id = ? AND user_id = ?", projectID, userID).First(&collab).Error; err != nil {
+            return err
+        }
+        result := tx.Delete(&collab)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return gorm.ErrRecordNotFound
+        }
+        return RecordProjectEvent(tx, models.ProjectEvent{
+            ProjectID: projectID,
+            Action: models.ProjectEventCollaboratorRemoved,
+            TargetUserID: &userID,
+            Changes: models.FieldChanges{"role": {Before: collab.Role}},
+        }, removedBy)
+    })
 }
 
 func IsOwner(db *gorm.DB, projectID, userID uint) (bool, error) {
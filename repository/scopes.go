@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"PA/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TaskSortKeys adalah kolom sort yang didukung untuk daftar task
+var TaskSortKeys = map[string]SortKey{
+	"deadline": {Column: "deadline", IsTime: true},
+	"created":  {Column: "created_at", IsTime: true},
+	"title":    {Column: "title"},
+}
+
+// ProjectSortKeys adalah kolom sort yang didukung untuk daftar project
+var ProjectSortKeys = map[string]SortKey{
+	"created": {Column: "created_at", IsTime: true},
+	"name":    {Column: "name"},
+}
+
+func TaskStatus(status string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if status == "" {
+			return db
+		}
+		return db.Where("LOWER(tasks.status) = LOWER(?)", status)
+	}
+}
+
+func TaskAssignee(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if userID == 0 {
+			return db
+		}
+		return db.Where("tasks.id IN (SELECT task_id FROM task_assignments WHERE user_id = ?)", userID)
+	}
+}
+
+func TaskDeadlineBefore(t *time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if t == nil {
+			return db
+		}
+		return db.Where("tasks.deadline < ?", *t)
+	}
+}
+
+func TaskDeadlineAfter(t *time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if t == nil {
+			return db
+		}
+		return db.Where("tasks.deadline > ?", *t)
+	}
+}
+
+func TaskSearch(query string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query = strings.TrimSpace(query)
+		if query == "" {
+			return db
+		}
+		pattern := "%" + escapeLike(query) + "%"
+		return db.Where("(tasks.title ILIKE ? OR tasks.description ILIKE ?)", pattern, pattern)
+	}
+}
+
+// TaskSprint membatasi task di sprint tertentu, atau task backlog yang belum masuk sprint
+func TaskSprint(sprintID uint, backlog bool) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if backlog {
+			return db.Where("tasks.sprint_id IS NULL")
+		}
+		if sprintID == 0 {
+			return db
+		}
+		return db.Where("tasks.sprint_id = ?", sprintID)
+	}
+}
+
+// TaskFilters menggabungkan semua scope filter task
+func TaskFilters(filter models.TaskFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Scopes(
+			TaskStatus(filter.Status),
+			TaskAssignee(filter.AssigneeID),
+			TaskDeadlineBefore(filter.DeadlineBefore),
+			TaskDeadlineAfter(filter.DeadlineAfter),
+			TaskSearch(filter.Query),
+			TaskSprint(filter.SprintID, filter.Backlog),
+		)
+	}
+}
+
+// ProjectMembership membatasi project yang dimiliki (owned), tempat user menjadi collaborator (shared), atau keduanya
+func ProjectMembership(userID uint, membership string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		shared := "projects.id IN (SELECT project_id FROM project_collaborators WHERE user_id = ?)"
+		switch membership {
+		case models.MembershipOwned:
+			return db.Where("projects.owner_id = ?", userID)
+		case models.MembershipShared:
+			return db.Where(shared+" AND projects.owner_id <> ?", userID, userID)
+		}
+		return db.Where("(projects.owner_id = ? OR "+shared+")", userID, userID)
+	}
+}
+
+// ProjectArchived menyembunyikan project yang diarsipkan (false), hanya menampilkannya (true), atau keduanya (all)
+func ProjectArchived(archived string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch archived {
+		case models.ArchivedOnly:
+			return db.Where("projects.archived_at IS NOT NULL")
+		case models.ArchivedAll:
+			return db
+		}
+		return db.Where("projects.archived_at IS NULL")
+	}
+}
+
+func ProjectSearch(query string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		query = strings.TrimSpace(query)
+		if query == "" {
+			return db
+		}
+		pattern := "%" + escapeLike(query) + "%"
+		return db.Where("(projects.name ILIKE ? OR projects.description ILIKE ?)", pattern, pattern)
+	}
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// selectUser membatasi preload user ke kolom publik saja
+func selectUser(db *gorm.DB) *gorm.DB {
+	return db.Select("id, username, email")
+}
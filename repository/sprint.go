@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"PA/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func GetProjectSprints(db *gorm.DB, projectID uint) ([]models.Sprint, error) {
+	var sprints []models.Sprint
+	err := db.Where("project_id = ?", projectID).Order("start_date, id").Find(&sprints).Error
+	return sprints, err
+}
+
+func GetSprint(db *gorm.DB, projectID, sprintID uint) (models.Sprint, error) {
+	var sprint models.Sprint
+	err := db.Where("project_id = ?", projectID).First(&sprint, sprintID).Error
+	return sprint, err
+}
+
+func CreateSprint(db *gorm.DB, sprint *models.Sprint) error {
+	return db.Create(sprint).Error
+}
+
+func UpdateSprint(db *gorm.DB, sprint *models.Sprint) error {
+	return db.Model(sprint).Select("name", "goal", "start_date", "end_date", "updated_at").Updates(sprint).Error
+}
+
+// ErrSprintStateChanged dikembalikan saat state sprint sudah diubah request lain
+var ErrSprintStateChanged = errors.New("sprint state changed")
+
+// SetSprintState memindahkan sprint dari state from ke state sprint.State secara atomik
+func SetSprintState(db *gorm.DB, sprint *models.Sprint, from string) error {
+	result := db.Model(sprint).Where("state = ?", from).Select("state", "closed_at", "closed_by_id", "updated_at").Updates(sprint)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSprintStateChanged
+	}
+	return nil
+}
+
+// DeleteSprint menghapus sprint dan mengembalikan task-nya ke backlog
+func DeleteSprint(db *gorm.DB, sprintID uint, deletedBy uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := moveSprintTasks(tx, sprintID, nil, nil, deletedBy); err != nil {
+			return err
+		}
+		// task di trash juga dilepas agar tidak menunjuk sprint yang sudah dihapus saat dipulihkan
+		if err := tx.Unscoped().Model(&models.Task{}).Where("sprint_id = ? AND deleted_at IS NOT NULL", sprintID).UpdateColumn("sprint_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Sprint{}, sprintID).Error
+	})
+}
+
+// SetTasksSprint memindahkan task ke sprint (nil = backlog). Version task dinaikkan agar ETag lama tidak berlaku lagi.
+func SetTasksSprint(db *gorm.DB, projectID uint, taskIDs []uint, sprintID *uint, movedBy uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		return moveTasks(tx, tx.Where("project_id = ? AND id IN ?", projectID, taskIDs), sprintID, movedBy)
+	})
+}
+
+// moveSprintTasks memindahkan task di sprint ke sprint lain (nil = backlog). taskIDs nil berarti semua task sprint.
+func moveSprintTasks(db *gorm.DB, fromSprintID uint, taskIDs []uint, toSprintID *uint, movedBy uint) error {
+	query := db.Where("sprint_id = ?", fromSprintID)
+	if taskIDs != nil {
+		if len(taskIDs) == 0 {
+			return nil
+		}
+		query = query.Where("id IN ?", taskIDs)
+	}
+	return moveTasks(db, query, toSprintID, movedBy)
+}
+
+// moveTasks mengubah sprint task yang cocok dengan query dan mencatat perpindahannya ke riwayat task.
+// Harus dipanggil di dalam transaksi.
+func moveTasks(db *gorm.DB, query *gorm.DB, sprintID *uint, movedBy uint) error {
+	var tasks []models.Task
+	if err := query.Select("id", "project_id", "sprint_id").Find(&tasks).Error; err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	err := db.Model(&models.Task{}).
+		Where("id IN ?", ids).
+		UpdateColumns(map[string]interface{}{
+			"sprint_id":  sprintID,
+			"version":    gorm.Expr("version + 1"),
+			"updated_at": time.Now(),
+		}).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		moved := tasks[i]
+		moved.SprintID = sprintID
+		changes := diffTask(&tasks[i], &moved, []string{"sprint_id"}, false)
+		if err := recordTaskEvent(db, &tasks[i], models.TaskEventUpdated, changes, movedBy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetSprintTasks mengembalikan seluruh task di sprint tanpa pagination
+func GetSprintTasks(db *gorm.DB, sprintID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := db.Where("sprint_id = ?", sprintID).Order("id").Find(&tasks).Error
+	return tasks, err
+}
+
+// CloseSprint menyimpan snapshot, memindahkan task yang belum selesai ke sprint berikutnya atau backlog,
+// lalu menutup sprint dalam satu transaksi
+func CloseSprint(db *gorm.DB, sprint *models.Sprint, snapshots []models.SprintTaskSnapshot, unfinished []uint, nextSprintID *uint, closedBy uint) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := SetSprintState(tx, sprint, models.SprintActive); err != nil {
+			return err
+		}
+		if len(snapshots) > 0 {
+			if err := tx.CreateInBatches(snapshots, 500).Error; err != nil {
+				return err
+			}
+		}
+		return moveSprintTasks(tx, sprint.ID, unfinished, nextSprintID, closedBy)
+	})
+}
+
+func GetSprintSnapshot(db *gorm.DB, sprintID uint) ([]models.SprintTaskSnapshot, error) {
+	var snapshots []models.SprintTaskSnapshot
+	err := db.Where("sprint_id = ?", sprintID).Order("id").Find(&snapshots).Error
+	return snapshots, err
+}
+
+// GetSprintVelocity menjumlahkan task yang selesai dari snapshot sprint closed yang berakhir di [from, to)
+func GetSprintVelocity(db *gorm.DB, projectID uint, from, to time.Time) ([]models.VelocityWindow, error) {
+	var rows []struct {
+		SprintID         uint
+		SprintName       string
+		StartDate        time.Time
+		EndDate          time.Time
+		CompletedTasks   int64
+		CompletedPoints  int64
+		CompletedHours   float64
+		UnestimatedTasks int64
+	}
+	err := db.Table("sprints").
+		Select("sprints.id AS sprint_id, sprints.name AS sprint_name, sprints.start_date, sprints.end_date, "+
+			"COUNT(s.id) AS completed_tasks, COALESCE(SUM(s.story_points), 0) AS completed_points, "+
+			"COALESCE(SUM(s.estimated_hours), 0) AS completed_hours, COUNT(s.id) - COUNT(s.story_points) AS unestimated_tasks").
+		Joins("LEFT JOIN sprint_task_snapshots s ON s.sprint_id = sprints.id AND s.completed").
+		Where("sprints.project_id = ? AND sprints.state = ? AND sprints.end_date >= ? AND sprints.end_date < ?", projectID, models.SprintClosed, from, to).
+		Group("sprints.id, sprints.name, sprints.start_date, sprints.end_date").
+		Order("sprints.end_date, sprints.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	windows := make([]models.VelocityWindow, len(rows))
+	for i, row := range rows {
+		sprintID := row.SprintID
+		windows[i] = models.VelocityWindow{
+			SprintID:         &sprintID,
+			SprintName:       row.SprintName,
+			Start:            row.StartDate,
+			End:              row.EndDate,
+			CompletedTasks:   row.CompletedTasks,
+			CompletedPoints:  row.CompletedPoints,
+			CompletedHours:   row.CompletedHours,
+			UnestimatedTasks: row.UnestimatedTasks,
+		}
+	}
+	return windows, nil
+}
+
+// deleteProjectSprints menghapus sprint beserta snapshot-nya saat project dihapus
+func deleteProjectSprints(db *gorm.DB, projectID uint) error {
+	if err := db.Where("sprint_id IN (SELECT id FROM sprints WHERE project_id = ?)", projectID).Delete(&models.SprintTaskSnapshot{}).Error; err != nil {
+		return err
+	}
+	return db.Where("project_id = ?", projectID).Delete(&models.Sprint{}).Error
+}
+
+// CountProjectTasks menghitung berapa dari taskIDs yang ada di project
+func CountProjectTasks(db *gorm.DB, projectID uint, taskIDs []uint) (int64, error) {
+	var count int64
+	err := db.Model(&models.Task{}).Where("project_id = ? AND id IN ?", projectID, taskIDs).Count(&count).Error
+	return count, err
+}
+
+// CountTasksInClosedSprints menghitung task yang tercatat di sprint yang sudah closed
+func CountTasksInClosedSprints(db *gorm.DB, taskIDs []uint) (int64, error) {
+	var count int64
+	err := db.Model(&models.Task{}).
+		Joins("JOIN sprints ON sprints.id = tasks.sprint_id").
+		Where("tasks.id IN ? AND sprints.state = ?", taskIDs, models.SprintClosed).
+		Count(&count).Error
+	return count, err
+}
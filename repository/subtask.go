@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"PA/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// subtaskLockKey adalah kunci advisory lock untuk perubahan hierarki subtask. Parent selalu berada di
+// project yang sama, jadi kunci dipasangkan dengan id project.
+const subtaskLockKey = 7302
+
+// LockTaskHierarchy menahan perubahan hierarki subtask lain di project yang sama sampai transaksi tx selesai,
+// sehingga pengecekan siklus dan kedalaman tidak bisa dilewati oleh dua perpindahan paralel
+func LockTaskHierarchy(tx *gorm.DB, projectID uint) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", subtaskLockKey, projectID).Error
+}
+
+// GetTaskLineage mengembalikan id task beserta seluruh parent-nya yang belum dihapus, dimulai dari task itu sendiri.
+// Pencarian dibatasi supaya data yang terlanjur siklik tidak membuat query berputar terus.
+func GetTaskLineage(db *gorm.DB, taskID uint) ([]uint, error) {
+	var ids []uint
+	err := db.Raw(`
+		WITH RECURSIVE lineage AS (
+			SELECT id, parent_id, 1 AS depth FROM tasks WHERE id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, t.parent_id, lineage.depth + 1 FROM tasks t JOIN lineage ON t.id = lineage.parent_id
+			WHERE t.deleted_at IS NULL AND lineage.depth <= ?
+		)
+		SELECT id FROM lineage ORDER BY depth`, taskID, models.MaxTaskDepth).Scan(&ids).Error
+	return ids, err
+}
+
+// GetSubtreeHeight mengembalikan jumlah level subtask yang belum dihapus di bawah task (0 jika tidak punya subtask)
+func GetSubtreeHeight(db *gorm.DB, taskID uint) (int, error) {
+	var height int
+	err := db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id, 1 AS depth FROM tasks WHERE parent_id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, tree.depth + 1 FROM tasks t JOIN tree ON t.parent_id = tree.id
+			WHERE t.deleted_at IS NULL AND tree.depth <= ?
+		)
+		SELECT COALESCE(MAX(depth), 0) FROM tree`, taskID, models.MaxTaskDepth).Scan(&height).Error
+	return height, err
+}
+
+// getSubtreeIDs mengembalikan id seluruh subtask (langsung maupun tidak langsung) yang belum dihapus
+func getSubtreeIDs(db *gorm.DB, taskID uint) ([]uint, error) {
+	var ids []uint
+	err := db.Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id, 1 AS depth FROM tasks WHERE parent_id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, tree.depth + 1 FROM tasks t JOIN tree ON t.parent_id = tree.id
+			WHERE t.deleted_at IS NULL AND tree.depth <= ?
+		)
+		SELECT id FROM tree`, taskID, models.MaxTaskDepth).Scan(&ids).Error
+	return ids, err
+}
+
+func GetSubtasks(db *gorm.DB, parentID uint) ([]models.Task, error) {
+	var tasks []models.Task
+	err := db.Preload("Assignments.User").
+		Where("parent_id = ?", parentID).
+		Order("created_at, id").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func GetChecklist(db *gorm.DB, taskID uint) ([]models.ChecklistItem, error) {
+	var items []models.ChecklistItem
+	err := db.Where("task_id = ?", taskID).Order("position, id").Find(&items).Error
+	return items, err
+}
+
+func GetChecklistItem(db *gorm.DB, taskID, itemID uint) (models.ChecklistItem, error) {
+	var item models.ChecklistItem
+	err := db.Where("task_id = ?", taskID).First(&item, itemID).Error
+	return item, err
+}
+
+// CreateChecklistItem menambahkan item di akhir checklist
+func CreateChecklistItem(db *gorm.DB, item *models.ChecklistItem) error {
+	var last int
+	err := db.Model(&models.ChecklistItem{}).
+		Where("task_id = ?", item.TaskID).
+		Select("COALESCE(MAX(position), 0)").
+		Scan(&last).Error
+	if err != nil {
+		return err
+	}
+	item.Position = last + 1
+	return db.Create(item).Error
+}
+
+func UpdateChecklistItem(db *gorm.DB, item *models.ChecklistItem) error {
+	if item.Done && item.DoneAt == nil {
+		now := time.Now()
+		item.DoneAt = &now
+	}
+	if !item.Done {
+		item.DoneAt = nil
+	}
+	return db.Model(item).Select("title", "done", "done_at").Updates(item).Error
+}
+
+func DeleteChecklistItem(db *gorm.DB, taskID, itemID uint) error {
+	result := db.Where("task_id = ?", taskID).Delete(&models.ChecklistItem{}, itemID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// detachSubtasks melepas subtask dari task yang akan dihapus sehingga subtask menjadi task utama,
+// lalu menghapus checklist task tersebut
+func detachSubtasks(db *gorm.DB, taskIDs []uint) error {
+	if err := db.Unscoped().Model(&models.Task{}).Where("parent_id IN ?", taskIDs).Update("parent_id", nil).Error; err != nil {
+		return err
+	}
+	return db.Where("task_id IN ?", taskIDs).Delete(&models.ChecklistItem{}).Error
+}
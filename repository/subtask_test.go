@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"PA/internal/fakedb"
+
+	"gorm.io/gorm"
+)
+
+// Subtask di trash tidak boleh ikut dihitung saat mengecek siklus dan kedalaman hierarki
+func TestHierarchyQueriesSkipTrashedTasks(t *testing.T) {
+	tests := []struct {
+		name  string
+		query func(db *gorm.DB) error
+	}{
+		{"lineage", func(db *gorm.DB) error { _, err := GetTaskLineage(db, 10); return err }},
+		{"tinggi subtree", func(db *gorm.DB) error { _, err := GetSubtreeHeight(db, 10); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, fake := fakedb.Open(t, nil)
+			if err := tt.query(db); err != nil {
+				t.Fatal(err)
+			}
+			queries := fake.Queries("")
+			if len(queries) != 1 {
+				t.Fatalf("jumlah query = %d, want 1", len(queries))
+			}
+			if count := strings.Count(queries[0].SQL, "deleted_at IS NULL"); count != 2 {
+				t.Errorf("filter deleted_at = %d, want 2 (anchor dan rekursi): %s", count, queries[0].SQL)
+			}
+		})
+	}
+}
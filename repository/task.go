@@ -6,86 +6,290 @@ import (
     "gorm.io/gorm"
 )
 
-func GetAllTask(db *gorm.DB, userID uint) ([]models.Task, error) {
+// GetAllTask mengembalikan task dari semua project user. Di project tempat role user termasuk
+// fullAccessRoles semua task dikembalikan, di project lain hanya task yang di-assign ke user.
+func GetAllTask(db *gorm.DB, userID uint, fullAccessRoles []string, filter models.TaskFilter, opts models.ListOptions) ([]models.Task, string, error) {
+    opts, key, err := normalizeSort(opts, TaskSortKeys)
+    if err != nil {
+        return nil, "", err
+    }
+    paginate, err := Paginate("tasks", key, opts)
+    if err != nil {
+        return nil, "", err
+    }
+
     var tasks []models.Task
-    err := db.
+    err = db.
         Preload("Assignments.User").
         Preload("Project").
         Joins("JOIN projects ON projects.id = tasks.project_id").
-        Where("tasks.id IN (SELECT task_id FROM task_assignments WHERE user_id = ?) OR projects.owner_id = ?", userID, userID).
+        Where(`(tasks.id IN (SELECT task_id FROM task_assignments WHERE user_id = ?)
+            OR (projects.owner_id = ? AND ? IN ?)
+            OR tasks.project_id IN (SELECT project_id FROM project_collaborators WHERE user_id = ? AND role IN ?))`,
+            userID, userID, models.RoleOwner, fullAccessRoles, userID, fullAccessRoles).
+        Scopes(TaskFilters(filter), paginate).
         Find(&tasks).Error
-    return tasks, err
+    if err != nil {
+        return nil, "", err
+    }
+
+    n, next := nextCursor(len(tasks), opts, func(i int) interface{} { return taskSortValue(tasks[i], opts.Sort) }, func(i int) uint { return tasks[i].ID })
+    return tasks[:n], next, nil
 }
 
 func GetTaskByID(db *gorm.DB, id uint) (models.Task, error) {
     var task models.Task
     err := db.
         Preload("Assignments.User").
-        Preload("Project").
+        Preload("Project.Collaborators").
         First(&task, id).Error
     return task, err
 }
 
-func GetTaskByProject(db *gorm.DB, projectID uint) ([]models.Task, error) {
+func GetTaskByProject(db *gorm.DB, projectID uint, filter models.TaskFilter, opts models.ListOptions) ([]models.Task, string, error) {
+    opts, key, err := normalizeSort(opts, TaskSortKeys)
+    if err != nil {
+        return nil, "", err
+    }
+    paginate, err := Paginate("tasks", key, opts)
+    if err != nil {
+        return nil, "", err
+    }
+
     var tasks []models.Task
-    err := db.
+    err = db.
         Preload("Assignments.User").
-        Preload("Project").
-        Where("project_id = ?", projectID).
+        Where("tasks.project_id = ?", projectID).
+        Scopes(TaskFilters(filter), paginate).
         Find(&tasks).Error
+    if err != nil {
+        return nil, "", err
+    }
+
+    n, next := nextCursor(len(tasks), opts, func(i int) interface{} { return taskSortValue(tasks[i], opts.Sort) }, func(i int) uint { return tasks[i].ID })
+    return tasks[:n], next, nil
+}
+
+// GetProjectTasks mengembalikan seluruh task project tanpa pagination, dipakai untuk perhitungan laporan
+func GetProjectTasks(db *gorm.DB, projectID uint) ([]models.Task, error) {
+    var tasks []models.Task
+    err := db.Where("project_id = ?", projectID).Order("id").Find(&tasks).Error
     return tasks, err
 }
 
-func CreateTask(db *gorm.DB, task *models.Task, userIDs []uint) error {
+func taskSortValue(task models.Task, sort string) interface{} {
+    switch sort {
+    case "deadline":
+        return task.Deadline
+    case "title":
+        return task.Title
+    }
+    return task.CreatedAt
+}
+
+func CreateTask(db *gorm.DB, task *models.Task, userIDs []uint, createdBy uint) error {
+    task.Version = 1
     return db.Transaction(func(tx *gorm.DB) error {
         if err := tx.Create(task).Error; err != nil {
             return err
         }
-        for _, userID := range userIDs {
-            assignment := models.TaskAssignment{
-                TaskID:     task.ID,
-                UserID:     userID,
-                AssignedAt: time.Now(),
+        if err := recordStatusChange(tx, task, "", createdBy); err != nil {
+            return err
+        }
+        if err := AddTaskAssignees(tx, task.ID, userIDs); err != nil {
+            return err
+        }
+        if err := reloadTask(tx, task); err != nil {
+            return err
+        }
+        return recordTaskEvent(tx, task, models.TaskEventCreated, taskSnapshot(task, false), createdBy)
+    })
+}
+
+// UpdateTask hanya mengubah kolom yang disebut di fields. userIDs nil berarti assignment tidak diubah,
+// selain itu assignment disamakan dengan userIDs tanpa menghapus assignment yang tetap ada.
+// Update memakai compare-and-swap terhadap task.Version, ErrStaleVersion jika version sudah berubah.
+// Perubahan status dicatat ke riwayat status, dan nilai sebelum/sesudah setiap field yang berubah
+// dicatat ke riwayat task atas nama changedBy dalam transaksi yang sama.
+func UpdateTask(db *gorm.DB, task *models.Task, fields []string, userIDs []uint, changedBy uint) error {
+    expected := task.Version
+    err := db.Transaction(func(tx *gorm.DB) error {
+        var previous models.Task
+        if err := tx.Preload("Assignments").First(&previous, task.ID).Error; err != nil {
+            return err
+        }
+
+        task.Version = expected + 1
+        task.UpdatedAt = time.Now()
+        columns := append([]string{"version", "updated_at"}, fields...)
+        result := tx.Model(task).Where("version = ?", expected).Select(columns).Updates(task)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return ErrStaleVersion
+        }
+        if hasField(fields, "status") && previous.Status != task.Status {
+            if err := recordStatusChange(tx, task, previous.Status, changedBy); err != nil {
+                return err
             }
-            if err := tx.Create(&assignment).Error; err != nil {
+        }
+        if userIDs != nil {
+            if err := SyncTaskAssignments(tx, task.ID, userIDs); err != nil {
                 return err
             }
         }
-        return tx.Preload("Assignments", func(db *gorm.DB) *gorm.DB {
-            return db.Preload("User")
-        }).Preload("Project").First(task).Error
+        if err := reloadTask(tx, task); err != nil {
+            return err
+        }
+        changes := diffTask(&previous, task, fields, userIDs != nil)
+        return recordTaskEvent(tx, task, updateEventAction(changes), changes, changedBy)
     })
+    if err != nil {
+        task.Version = expected
+    }
+    return err
+}
+
+func hasField(fields []string, name string) bool {
+    for _, field := range fields {
+        if field == name {
+            return true
+        }
+    }
+    return false
 }
 
-func UpdateTask(db *gorm.DB, task *models.Task, userIDs []uint) error {
+func reloadTask(db *gorm.DB, task *models.Task) error {
+    return db.Preload("Assignments", func(db *gorm.DB) *gorm.DB {
+        return db.Preload("User")
+    }).Preload("Project").First(task, task.ID).Error
+}
+
+// SyncTaskAssignments menghapus assignment yang tidak ada di userIDs dan menambah yang belum ada,
+// sehingga AssignedAt milik assignment lama tetap terjaga
+func SyncTaskAssignments(db *gorm.DB, taskID uint, userIDs []uint) error {
+    var current []uint
+    if err := db.Model(&models.TaskAssignment{}).Where("task_id = ?", taskID).Pluck("user_id", &current).Error; err != nil {
+        return err
+    }
+
+    wanted := make(map[uint]bool, len(userIDs))
+    for _, userID := range userIDs {
+        wanted[userID] = true
+    }
+
+    removed := []uint{}
+    for _, userID := range current {
+        if !wanted[userID] {
+            removed = append(removed, userID)
+        }
+    }
+    if len(removed) > 0 {
+        if err := db.Where("task_id = ? AND user_id IN ?", taskID, removed).Delete(&models.TaskAssignment{}).Error; err != nil {
+            return err
+        }
+    }
+
+    return AddTaskAssignees(db, taskID, userIDs)
+}
+
+// AddTaskAssignees menambahkan assignment untuk user yang belum di-assign
+func AddTaskAssignees(db *gorm.DB, taskID uint, userIDs []uint) error {
+    var current []uint
+    if err := db.Model(&models.TaskAssignment{}).Where("task_id = ?", taskID).Pluck("user_id", &current).Error; err != nil {
+        return err
+    }
+
+    assigned := make(map[uint]bool, len(current))
+    for _, userID := range current {
+        assigned[userID] = true
+    }
+
+    for _, userID := range userIDs {
+        if assigned[userID] {
+            continue
+        }
+        assignment := models.TaskAssignment{
+            TaskID:     taskID,
+            UserID:     userID,
+            AssignedAt: time.Now(),
+        }
+        if err := db.Create(&assignment).Error; err != nil {
+            return err
+        }
+        assigned[userID] = true
+    }
+    return nil
+}
+
+func RemoveTaskAssignee(db *gorm.DB, taskID, userID uint) error {
+    result := db.Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskAssignment{})
+    if result.Error != nil {
+        return result.Error
+    }
+    if result.RowsAffected == 0 {
+        return gorm.ErrRecordNotFound
+    }
+    return nil
+}
+
+// DeleteTask memindahkan task beserta seluruh subtask-nya ke trash. Semua task yang dihapus bersamaan
+// mendapat deleted_at yang sama sehingga dipulihkan bersama oleh RestoreTask. Relasi task tetap disimpan
+// sampai task dihapus permanen oleh PurgeTrash. Nilai terakhir setiap task dicatat sebagai event deleted.
+func DeleteTask(db *gorm.DB, id uint, version uint, deletedBy uint) error {
     return db.Transaction(func(tx *gorm.DB) error {
-        if err := tx.Model(task).Updates(task).Error; err != nil {
+        subtaskIDs, err := getSubtreeIDs(tx, id)
+        if err != nil {
             return err
         }
-        if err := tx.Where("task_id = ?", task.ID).Delete(&models.TaskAssignment{}).Error; err != nil {
+        var tasks []models.Task
+        if err := tx.Preload("Assignments").Where("id IN ?", append([]uint{id}, subtaskIDs...)).Order("id").Find(&tasks).Error; err != nil {
             return err
         }
-        for _, userID := range userIDs {
-            assignment := models.TaskAssignment{
-                TaskID:     task.ID,
-                UserID:     userID,
-                AssignedAt: time.Now(),
+
+        columns := trashColumns(deletedBy)
+        result := tx.Model(&models.Task{}).Where("id = ? AND version = ?", id, version).UpdateColumns(columns)
+        if result.Error != nil {
+            return result.Error
+        }
+        if result.RowsAffected == 0 {
+            return ErrStaleVersion
+        }
+        if len(subtaskIDs) > 0 {
+            if err := tx.Model(&models.Task{}).Where("id IN ?", subtaskIDs).UpdateColumns(columns).Error; err != nil {
+                return err
             }
-            if err := tx.Create(&assignment).Error; err != nil {
+        }
+
+        for i := range tasks {
+            if err := recordTaskEvent(tx, &tasks[i], models.TaskEventDeleted, taskSnapshot(&tasks[i], true), deletedBy); err != nil {
                 return err
             }
         }
-        return tx.Preload("Assignments", func(db *gorm.DB) *gorm.DB {
-            return db.Preload("User")
-        }).Preload("Project").First(task).Error
+        return nil
     })
 }
 
-func DeleteTask(db *gorm.DB, id uint) error {
-    return db.Transaction(func(tx *gorm.DB) error {
-        if err := tx.Where("task_id = ?", id).Delete(&models.TaskAssignment{}).Error; err != nil {
-            return err
-        }
-        return tx.Delete(&models.Task{}, id).Error
-    })
-}
\ No newline at end of file
+// deleteTaskRelations membersihkan data yang bergantung pada task sebelum task dihapus
+func deleteTaskRelations(db *gorm.DB, taskIDs []uint) error {
+    if err := deleteTaskComments(db, taskIDs); err != nil {
+        return err
+    }
+    if err := detachSubtasks(db, taskIDs); err != nil {
+        return err
+    }
+    if err := deleteTaskAttachments(db, taskIDs); err != nil {
+        return err
+    }
+    if err := deleteTaskTimeEntries(db, taskIDs); err != nil {
+        return err
+    }
+    if err := deleteTaskStatusChanges(db, taskIDs); err != nil {
+        return err
+    }
+    if err := deleteTaskLabels(db, taskIDs); err != nil {
+        return err
+    }
+    return deleteTaskDependencies(db, taskIDs)
+}
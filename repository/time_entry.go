@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"PA/models"
+
+	"gorm.io/gorm"
+)
+
+func GetRunningTimer(db *gorm.DB, userID uint) (models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := db.Where("user_id = ? AND ended_at IS NULL", userID).First(&entry).Error
+	return entry, err
+}
+
+func CreateTimeEntry(db *gorm.DB, entry *models.TimeEntry) error {
+	return db.Create(entry).Error
+}
+
+// StopTimer menutup timer yang masih berjalan. Kondisi ended_at IS NULL mencegah timer dihentikan dua kali.
+func StopTimer(db *gorm.DB, entry *models.TimeEntry) error {
+	result := db.Model(entry).Where("ended_at IS NULL").Select("ended_at", "duration_seconds", "updated_at").Updates(entry)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func GetTimeEntry(db *gorm.DB, taskID, entryID uint) (models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := db.Where("task_id = ?", taskID).First(&entry, entryID).Error
+	return entry, err
+}
+
+func UpdateTimeEntry(db *gorm.DB, entry *models.TimeEntry) error {
+	return db.Model(entry).Select("started_at", "ended_at", "duration_seconds", "note", "updated_at").Updates(entry).Error
+}
+
+func DeleteTimeEntry(db *gorm.DB, entryID uint) error {
+	return db.Delete(&models.TimeEntry{}, entryID).Error
+}
+
+func GetTaskTimeEntries(db *gorm.DB, taskID uint, timeRange models.TimeRange) ([]models.TimeEntry, error) {
+	var entries []models.TimeEntry
+	err := db.Scopes(TimeEntryRange(timeRange)).
+		Where("time_entries.task_id = ?", taskID).
+		Order("time_entries.started_at DESC, time_entries.id DESC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// TimeEntryRange adalah scope filter started_at, hanya entry yang sudah selesai yang dihitung pada total
+func TimeEntryRange(timeRange models.TimeRange) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if timeRange.From != nil {
+			db = db.Where("time_entries.started_at >= ?", *timeRange.From)
+		}
+		if timeRange.To != nil {
+			db = db.Where("time_entries.started_at < ?", *timeRange.To)
+		}
+		return db
+	}
+}
+
+// sumTime menjumlahkan durasi entry yang sudah selesai, dikelompokkan berdasarkan kolom id dan nama yang diberikan
+func sumTime(db *gorm.DB, idColumn, nameColumn string) ([]models.TimeTotal, error) {
+	var totals []models.TimeTotal
+	err := db.Select(idColumn + " AS id, " + nameColumn + " AS name, SUM(time_entries.duration_seconds) AS seconds").
+		Where("time_entries.ended_at IS NOT NULL").
+		Group(idColumn + ", " + nameColumn).
+		Order("seconds DESC").
+		Scan(&totals).Error
+	return totals, err
+}
+
+func timeQuery(db *gorm.DB, timeRange models.TimeRange) *gorm.DB {
+	return db.Table("time_entries").
+		Joins("JOIN tasks ON tasks.id = time_entries.task_id AND tasks.deleted_at IS NULL").
+		Joins("JOIN users ON users.id = time_entries.user_id").
+		Joins("JOIN projects ON projects.id = tasks.project_id AND projects.deleted_at IS NULL").
+		Scopes(TimeEntryRange(timeRange))
+}
+
+func summarize(totals []models.TimeTotal) int64 {
+	var sum int64
+	for _, total := range totals {
+		sum += total.Seconds
+	}
+	return sum
+}
+
+func GetTaskTimeSummary(db *gorm.DB, taskID uint, timeRange models.TimeRange) (models.TimeSummary, error) {
+	byUser, err := sumTime(timeQuery(db, timeRange).Where("time_entries.task_id = ?", taskID), "users.id", "users.username")
+	if err != nil {
+		return models.TimeSummary{}, err
+	}
+	return models.TimeSummary{TotalSeconds: summarize(byUser), ByUser: byUser}, nil
+}
+
+func GetProjectTimeSummary(db *gorm.DB, projectID uint, timeRange models.TimeRange) (models.TimeSummary, error) {
+	byUser, err := sumTime(timeQuery(db, timeRange).Where("tasks.project_id = ?", projectID), "users.id", "users.username")
+	if err != nil {
+		return models.TimeSummary{}, err
+	}
+	byTask, err := sumTime(timeQuery(db, timeRange).Where("tasks.project_id = ?", projectID), "tasks.id", "tasks.title")
+	if err != nil {
+		return models.TimeSummary{}, err
+	}
+	return models.TimeSummary{TotalSeconds: summarize(byUser), ByUser: byUser, ByTask: byTask}, nil
+}
+
+func GetUserTimeSummary(db *gorm.DB, userID uint, timeRange models.TimeRange) (models.TimeSummary, error) {
+	byProject, err := sumTime(timeQuery(db, timeRange).Where("time_entries.user_id = ?", userID), "projects.id", "projects.name")
+	if err != nil {
+		return models.TimeSummary{}, err
+	}
+	byTask, err := sumTime(timeQuery(db, timeRange).Where("time_entries.user_id = ?", userID), "tasks.id", "tasks.title")
+	if err != nil {
+		return models.TimeSummary{}, err
+	}
+	return models.TimeSummary{TotalSeconds: summarize(byProject), ByProject: byProject, ByTask: byTask}, nil
+}
+
+func deleteTaskTimeEntries(db *gorm.DB, taskIDs []uint) error {
+	return db.Where("task_id IN ?", taskIDs).Delete(&models.TimeEntry{}).Error
+}
+
+// StreamTimesheet membaca time entry yang sudah selesai baris demi baris dan memanggil fn untuk setiap baris,
+// diurutkan sesuai pengelompokan sehingga subtotal bisa dihitung tanpa menampung seluruh data.
+func StreamTimesheet(db *gorm.DB, filter models.TimesheetFilter, fn func(models.TimesheetRow) error) error {
+	query := timeQuery(db, filter.Range).
+		Select("time_entries.id AS entry_id, time_entries.started_at, time_entries.ended_at, time_entries.duration_seconds, time_entries.note, " +
+			"users.id AS user_id, users.username, projects.id AS project_id, projects.name AS project_name, tasks.id AS task_id, tasks.title AS task_title").
+		Where("time_entries.ended_at IS NOT NULL")
+	if filter.ProjectID != 0 {
+		query = query.Where("tasks.project_id = ?", filter.ProjectID)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("time_entries.user_id = ?", filter.UserID)
+	}
+
+	switch filter.GroupBy {
+	case models.TimesheetGroupTask:
+		query = query.Order("projects.name, projects.id, tasks.title, tasks.id")
+	case models.TimesheetGroupUser:
+		query = query.Order("users.username, users.id")
+	}
+	query = query.Order("time_entries.started_at, time_entries.id")
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.TimesheetRow
+		if err := db.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"PA/models"
+	"PA/utils"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func CreateRefreshToken(db *gorm.DB, token *models.RefreshToken) error {
+	return db.Create(token).Error
+}
+
+func GetRefreshTokenByHash(db *gorm.DB, hash string) (models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := db.Where("token_hash = ?", hash).First(&token).Error
+	return token, err
+}
+
+// RotateRefreshToken mencabut token lama hanya jika belum dicabut, sehingga refresh paralel dengan token yang sama terdeteksi sebagai reuse
+func RotateRefreshToken(db *gorm.DB, oldID uint, newToken *models.RefreshToken) (bool, error) {
+	rotated := false
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(newToken).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", oldID).
+			Updates(map[string]interface{}{
+				"revoked_at":     time.Now(),
+				"replaced_by_id": newToken.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		rotated = true
+		return nil
+	})
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return rotated, err
+}
+
+func RevokeTokenFamily(db *gorm.DB, familyID string) error {
+	return db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func RevokeUserRefreshTokens(db *gorm.DB, userID uint) error {
+	return db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func RevokeAccessToken(db *gorm.DB, jti string, userID uint, expiresAt time.Time) error {
+	revoked := models.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}
+	return db.Where(models.RevokedToken{JTI: jti}).FirstOrCreate(&revoked).Error
+}
+
+// RevokeUserAccessTokens menolak semua access token user yang diterbitkan sebelum before
+func RevokeUserAccessTokens(db *gorm.DB, userID uint, before time.Time) error {
+	return db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("tokens_revoked_at", before.Truncate(time.Millisecond)).Error
+}
+
+// IsAccessTokenRevoked mengecek apakah access token dicabut satu per satu (logout) atau diterbitkan
+// sebelum batas tokens_revoked_at milik user (logout semua sesi)
+func IsAccessTokenRevoked(db *gorm.DB, userID uint, session utils.TokenClaims) (bool, error) {
+	var revoked bool
+	err := db.Raw(`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = ?)
+		OR EXISTS (SELECT 1 FROM users WHERE id = ? AND tokens_revoked_at > ?)`,
+		session.ID, userID, session.IssuedAt).Scan(&revoked).Error
+	return revoked, err
+}
+
+// PurgeExpiredTokens membersihkan token yang sudah kadaluarsa karena tidak perlu dicek lagi
+func PurgeExpiredTokens(db *gorm.DB) error {
+	now := time.Now()
+	if err := db.Where("expires_at < ?", now).Delete(&models.RevokedToken{}).Error; err != nil {
+		return err
+	}
+	return db.Where("expires_at < ?", now).Delete(&models.RefreshToken{}).Error
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"PA/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// trashColumns mengisi deleted_at dan deleted_by_id. Waktu dipotong ke mikrodetik (presisi timestamp
+// postgres) supaya seluruh baris yang dihapus dalam satu operasi bisa dicocokkan kembali saat restore.
+func trashColumns(deletedBy uint) map[string]interface{} {
+	columns := map[string]interface{}{
+		"deleted_at":    time.Now().UTC().Truncate(time.Microsecond),
+		"deleted_by_id": nil,
+		"version":       gorm.Expr("version + 1"),
+	}
+	if deletedBy != 0 {
+		columns["deleted_by_id"] = deletedBy
+	}
+	return columns
+}
+
+func restoreColumns() map[string]interface{} {
+	return map[string]interface{}{
+		"deleted_at":    nil,
+		"deleted_by_id": nil,
+		"version":       gorm.Expr("version + 1"),
+		"updated_at":    time.Now(),
+	}
+}
+
+// GetTrashedProject memuat project yang ada di trash beserta collaborator-nya
+func GetTrashedProject(db *gorm.DB, projectID uint) (models.Project, error) {
+	var project models.Project
+	err := db.Unscoped().
+		Preload("Collaborators").
+		Where("deleted_at IS NOT NULL").
+		First(&project, projectID).Error
+	return project, err
+}
+
+// GetTrashedTask memuat task yang ada di trash beserta data yang dibutuhkan policy. Project yang ikut
+// di trash tetap dimuat agar pemanggil bisa menolak restore task sebelum project-nya dipulihkan.
+func GetTrashedTask(db *gorm.DB, taskID uint) (models.Task, error) {
+	var task models.Task
+	err := db.Unscoped().
+		Preload("Assignments").
+		Preload("Project", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped().Preload("Collaborators")
+		}).
+		Where("deleted_at IS NOT NULL").
+		First(&task, taskID).Error
+	return task, err
+}
+
+// IsTaskTrashed mengecek apakah task masih ada tetapi berada di trash
+func IsTaskTrashed(db *gorm.DB, taskID uint) (bool, error) {
+	var count int64
+	err := db.Unscoped().Model(&models.Task{}).Where("id = ? AND deleted_at IS NOT NULL", taskID).Count(&count).Error
+	return count > 0, err
+}
+
+// RestoreProject memulihkan project beserta task yang dihapus bersamaan dengannya
+func RestoreProject(db *gorm.DB, project *models.Project, restoredBy uint) error {
+	deletedAt := project.DeletedAt.Time
+	return db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&models.Project{}).
+			Where("id = ? AND deleted_at = ?", project.ID, deletedAt).
+			UpdateColumns(restoreColumns())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleVersion
+		}
+		err := tx.Unscoped().Model(&models.Task{}).
+			Where("project_id = ? AND deleted_at = ?", project.ID, deletedAt).
+			UpdateColumns(restoreColumns()).Error
+		if err != nil {
+			return err
+		}
+		if err := RecordProjectEvent(tx, models.ProjectEvent{ProjectID: project.ID, Action: models.ProjectEventRestored}, restoredBy); err != nil {
+			return err
+		}
+		return tx.First(project, project.ID).Error
+	})
+}
+
+// RestoreTask memulihkan task beserta subtask yang dihapus bersamaan dengannya (project dan deleted_at sama)
+func RestoreTask(db *gorm.DB, task *models.Task, restoredBy uint) error {
+	deletedAt := task.DeletedAt.Time
+	return db.Transaction(func(tx *gorm.DB) error {
+		var ids []uint
+		err := tx.Unscoped().Model(&models.Task{}).
+			Where("project_id = ? AND deleted_at = ?", task.ProjectID, deletedAt).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Model(&models.Task{}).
+			Where("id IN ? AND deleted_at = ?", ids, deletedAt).
+			UpdateColumns(restoreColumns())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleVersion
+		}
+
+		var restored []models.Task
+		if err := tx.Preload("Assignments").Where("id IN ?", ids).Order("id").Find(&restored).Error; err != nil {
+			return err
+		}
+		for i := range restored {
+			if err := recordTaskEvent(tx, &restored[i], models.TaskEventRestored, taskSnapshot(&restored[i], false), restoredBy); err != nil {
+				return err
+			}
+		}
+		return reloadTask(tx, task)
+	})
+}
+
+// GetTrashedProjects mengembalikan project milik user yang ada di trash
+func GetTrashedProjects(db *gorm.DB, userID uint) ([]models.TrashItem, error) {
+	var items []models.TrashItem
+	err := db.Table("projects").
+		Select("'"+models.TrashProject+"' AS type, projects.id, projects.name, projects.id AS project_id, projects.name AS project_name, "+
+			"projects.deleted_at, projects.deleted_by_id, COALESCE(users.username, '') AS deleted_by, '"+models.RoleOwner+"' AS role, "+
+			"(SELECT COUNT(*) FROM tasks WHERE tasks.project_id = projects.id AND tasks.deleted_at = projects.deleted_at) AS task_count").
+		Joins("LEFT JOIN users ON users.id = projects.deleted_by_id").
+		Where("projects.owner_id = ? AND projects.deleted_at IS NOT NULL", userID).
+		Order("projects.deleted_at DESC, projects.id DESC").
+		Scan(&items).Error
+	return items, err
+}
+
+// GetTrashedTasks mengembalikan task di trash dari project aktif tempat user menjadi anggota, beserta role
+// user di project tersebut. Subtask yang terhapus bersama induknya tidak dikembalikan sendiri.
+func GetTrashedTasks(db *gorm.DB, userID uint) ([]models.TrashItem, error) {
+	var items []models.TrashItem
+	err := db.Table("tasks").
+		Select("'"+models.TrashTask+"' AS type, tasks.id, tasks.title AS name, projects.id AS project_id, projects.name AS project_name, "+
+			"tasks.deleted_at, tasks.deleted_by_id, COALESCE(users.username, '') AS deleted_by, "+
+			"CASE WHEN projects.owner_id = ? THEN '"+models.RoleOwner+"' ELSE "+
+			"(SELECT role FROM project_collaborators WHERE project_id = projects.id AND user_id = ?) END AS role, "+
+			"(SELECT COUNT(*) FROM tasks batch WHERE batch.project_id = tasks.project_id AND batch.deleted_at = tasks.deleted_at AND batch.id <> tasks.id) AS task_count", userID, userID).
+		Joins("JOIN projects ON projects.id = tasks.project_id AND projects.deleted_at IS NULL").
+		Joins("LEFT JOIN users ON users.id = tasks.deleted_by_id").
+		Scopes(ProjectMembership(userID, models.MembershipAll)).
+		Where("tasks.deleted_at IS NOT NULL").
+		Where("NOT EXISTS (SELECT 1 FROM tasks parent WHERE parent.id = tasks.parent_id AND parent.deleted_at = tasks.deleted_at)").
+		Order("tasks.deleted_at DESC, tasks.id DESC").
+		Scan(&items).Error
+	return items, err
+}
+
+// PurgeTrash menghapus permanen project dan task yang masuk trash sebelum waktu tertentu.
+// Setiap project dihapus dalam transaksinya sendiri, task dihapus per batch.
+func PurgeTrash(db *gorm.DB, before time.Time) (projects int, tasks int, err error) {
+	var projectIDs []uint
+	err = db.Unscoped().Model(&models.Project{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Pluck("id", &projectIDs).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, projectID := range projectIDs {
+		if err := db.Transaction(func(tx *gorm.DB) error { return purgeProject(tx, projectID) }); err != nil {
+			return projects, tasks, err
+		}
+		projects++
+	}
+
+	for {
+		var taskIDs []uint
+		err = db.Unscoped().Model(&models.Task{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Limit(purgeBatchSize).
+			Pluck("id", &taskIDs).Error
+		if err != nil || len(taskIDs) == 0 {
+			return projects, tasks, err
+		}
+		if err := db.Transaction(func(tx *gorm.DB) error { return purgeTasks(tx, taskIDs) }); err != nil {
+			return projects, tasks, err
+		}
+		tasks += len(taskIDs)
+	}
+}
+
+const purgeBatchSize = 500
+
+func purgeTasks(db *gorm.DB, taskIDs []uint) error {
+	if err := db.Where("task_id IN ?", taskIDs).Delete(&models.TaskAssignment{}).Error; err != nil {
+		return err
+	}
+	if err := deleteTaskRelations(db, taskIDs); err != nil {
+		return err
+	}
+	return db.Unscoped().Where("id IN ?", taskIDs).Delete(&models.Task{}).Error
+}
+
+// purgeProject menghapus permanen project beserta collaborator, seluruh task, sprint, label dan workflow-nya.
+// Riwayat project dan task tidak ikut dihapus.
+func purgeProject(db *gorm.DB, projectID uint) error {
+	if err := db.Where("project_id = ?", projectID).Delete(&models.ProjectCollaborator{}).Error; err != nil {
+		return err
+	}
+
+	var taskIDs []uint
+	if err := db.Unscoped().Model(&models.Task{}).Where("project_id = ?", projectID).Pluck("id", &taskIDs).Error; err != nil {
+		return err
+	}
+	if len(taskIDs) > 0 {
+		if err := purgeTasks(db, taskIDs); err != nil {
+			return err
+		}
+	}
+
+	if err := deleteProjectSprints(db, projectID); err != nil {
+		return err
+	}
+	if err := deleteProjectLabels(db, projectID); err != nil {
+		return err
+	}
+	if err := db.Where("project_id = ?", projectID).Delete(&models.WorkflowTransition{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("project_id = ?", projectID).Delete(&models.WorkflowStatus{}).Error; err != nil {
+		return err
+	}
+	return db.Unscoped().Delete(&models.Project{}, projectID).Error
+}
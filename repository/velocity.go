@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"PA/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// GetVelocityWindows menjumlahkan task yang selesai di [from, to) per window sepanjang window,
+// dihitung sejak from. Window tanpa task selesai tetap dikembalikan dengan nilai 0.
+func GetVelocityWindows(db *gorm.DB, projectID uint, from, to time.Time, window time.Duration) ([]models.VelocityWindow, error) {
+	var rows []struct {
+		Bucket           int
+		CompletedTasks   int64
+		CompletedPoints  int64
+		CompletedHours   float64
+		UnestimatedTasks int64
+	}
+	err := db.Model(&models.Task{}).
+		Select("FLOOR((EXTRACT(EPOCH FROM completed_at) - ?) / ?)::int AS bucket, COUNT(*) AS completed_tasks, "+
+			"COALESCE(SUM(story_points), 0) AS completed_points, COALESCE(SUM(estimated_hours), 0) AS completed_hours, "+
+			"COUNT(*) - COUNT(story_points) AS unestimated_tasks", from.Unix(), int64(window/time.Second)).
+		Where("project_id = ? AND completed_at >= ? AND completed_at < ?", projectID, from, to).
+		Group("bucket").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	windows := []models.VelocityWindow{}
+	for start := from; start.Before(to); start = start.Add(window) {
+		end := start.Add(window)
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, models.VelocityWindow{Start: start, End: end})
+	}
+	for _, row := range rows {
+		if row.Bucket < 0 || row.Bucket >= len(windows) {
+			continue
+		}
+		windows[row.Bucket].CompletedTasks = row.CompletedTasks
+		windows[row.Bucket].CompletedPoints = row.CompletedPoints
+		windows[row.Bucket].CompletedHours = row.CompletedHours
+		windows[row.Bucket].UnestimatedTasks = row.UnestimatedTasks
+	}
+	return windows, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"PA/models"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetWorkflow memuat status (terurut) dan transisi project, nama status transisi ikut diisi
+func GetWorkflow(db *gorm.DB, projectID uint) (models.Workflow, error) {
+	var workflow models.Workflow
+	if err := db.Where("project_id = ?", projectID).Order("position, id").Find(&workflow.Statuses).Error; err != nil {
+		return workflow, err
+	}
+	if err := db.Where("project_id = ?", projectID).Order("id").Find(&workflow.Transitions).Error; err != nil {
+		return workflow, err
+	}
+
+	names := make(map[uint]string, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		names[status.ID] = status.Name
+	}
+	for i := range workflow.Transitions {
+		workflow.Transitions[i].From = names[workflow.Transitions[i].FromStatusID]
+		workflow.Transitions[i].To = names[workflow.Transitions[i].ToStatusID]
+	}
+	return workflow, nil
+}
+
+// SaveWorkflow mengganti seluruh workflow project. Status dengan ID yang sudah ada diupdate,
+// status tanpa ID dibuat baru, sisanya dihapus. renames (nama lama -> nama baru) diterapkan ke task.
+func SaveWorkflow(db *gorm.DB, projectID uint, workflow models.Workflow, renames map[string]string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&models.WorkflowTransition{}).Error; err != nil {
+			return err
+		}
+
+		keep := make([]uint, 0, len(workflow.Statuses))
+		ids := make(map[string]uint, len(workflow.Statuses))
+		for i := range workflow.Statuses {
+			status := &workflow.Statuses[i]
+			status.ProjectID = projectID
+			if err := tx.Save(status).Error; err != nil {
+				return err
+			}
+			keep = append(keep, status.ID)
+			ids[strings.ToLower(status.Name)] = status.ID
+		}
+
+		if err := tx.Where("project_id = ? AND id NOT IN ?", projectID, keep).Delete(&models.WorkflowStatus{}).Error; err != nil {
+			return err
+		}
+
+		for _, transition := range workflow.Transitions {
+			record := models.WorkflowTransition{
+				ProjectID:    projectID,
+				FromStatusID: ids[strings.ToLower(transition.From)],
+				ToStatusID:   ids[strings.ToLower(transition.To)],
+			}
+			if err := tx.Create(&record).Error; err != nil {
+				return err
+			}
+		}
+
+		return renameTaskStatuses(tx, projectID, renames)
+	})
+}
+
+// CreateDefaultWorkflow membuat workflow bawaan untuk project
+func CreateDefaultWorkflow(db *gorm.DB, projectID uint) error {
+	return SaveWorkflow(db, projectID, models.DefaultWorkflow(), nil)
+}
+
+// renameTaskStatuses memakai satu UPDATE dengan CASE supaya pertukaran nama (A<->B) tidak saling menimpa.
+// Task di trash ikut diganti agar statusnya tetap valid saat dipulihkan.
+func renameTaskStatuses(db *gorm.DB, projectID uint, renames map[string]string) error {
+	if len(renames) == 0 {
+		return nil
+	}
+
+	expr, olds := renameCase("status", renames)
+	err := db.Unscoped().Model(&models.Task{}).
+		Where("project_id = ? AND status IN ?", projectID, olds).
+		Update("status", expr).Error
+	if err != nil {
+		return err
+	}
+	return renameStatusHistory(db, projectID, renames)
+}
+
+// renameCase membentuk ekspresi CASE column WHEN lama THEN baru ... ELSE column END beserta daftar nama lama
+func renameCase(column string, renames map[string]string) (clause.Expr, []string) {
+	var sql strings.Builder
+	args := make([]interface{}, 0, len(renames)*2)
+	olds := make([]string, 0, len(renames))
+	sql.WriteString("CASE " + column)
+	for oldName, newName := range renames {
+		sql.WriteString(" WHEN ? THEN ?")
+		args = append(args, oldName, newName)
+		olds = append(olds, oldName)
+	}
+	sql.WriteString(" ELSE " + column + " END")
+	return gorm.Expr(sql.String(), args...), olds
+}
+
+// CountTasksWithStatus ikut menghitung task di trash supaya status yang masih dipakai task tersebut tidak dihapus
+func CountTasksWithStatus(db *gorm.DB, projectID uint, status string) (int64, error) {
+	var count int64
+	err := db.Unscoped().Model(&models.Task{}).Where("project_id = ? AND status = ?", projectID, status).Count(&count).Error
+	return count, err
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"PA/controllers"
 	"PA/middleware"
+	"PA/storage"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -11,24 +12,37 @@ import (
    	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func SetupRouter(db *gorm.DB) *gin.Engine {
+func SetupRouter(db *gorm.DB, store storage.Store) *gin.Engine {
 	router := gin.Default()
 
 	docs.SwaggerInfo.BasePath = "/"
 
 	router.Use(func(c *gin.Context) {
 		c.Set("db", db)
+		c.Set("storage", store)
 		c.Next()
 	})
+	router.Use(middleware.ErrorHandler())
 
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
 	router.POST("/api/register", controllers.Register)
 	router.POST("/api/login", controllers.Login)
+	router.POST("/api/token/refresh", controllers.RefreshTokenController)
 
 	auth := router.Group("/api")
 	auth.Use(middleware.AuthMiddleware())
 	{
+		auth.POST("/logout", controllers.LogoutController)
+
+		auth.GET("/me/timer", controllers.GetRunningTimerController)
+		auth.GET("/me/time", controllers.GetMyTimeController)
+		auth.GET("/me/timesheet", controllers.ExportMyTimesheetController)
+
+		auth.GET("/trash", controllers.GetTrashController)
+		auth.POST("/trash/projects/:project_id/restore", controllers.RestoreProjectController)
+		auth.POST("/trash/tasks/:id/restore", controllers.RestoreTaskController)
+
 		setupProjectRoutes(auth)
 		setupTaskRoutes(auth)
 	}
@@ -41,18 +55,60 @@ func setupProjectRoutes(rg *gin.RouterGroup) {
 	{
 		projects.POST("/", controllers.AddProjectController)
 		projects.GET("/", controllers.GetProjectsController)
-		projects.GET("/:project_id", controllers.GetProjectByIDController)
-		projects.PUT("/:project_id", controllers.EditProjectController)
-		projects.DELETE("/:project_id", controllers.DeleteProjectController)
-		
-		projects.POST("/:project_id/collaborators", controllers.AddCollaboratorController)
-		projects.DELETE("/:project_id/collaborators", controllers.RemoveCollaboratorController)
-
-		tasks := projects.Group("/:project_id/tasks")
+		// Transfer tidak memakai LoadProject karena admin sistem boleh melakukannya tanpa menjadi anggota project
+		projects.POST("/:project_id/transfer", controllers.TransferProjectOwnershipController)
+
+		// Semua route di bawah :project_id memakai project yang dimuat sekali oleh LoadProject
+		project := projects.Group("/:project_id", middleware.LoadProject())
 		{
-			tasks.POST("/", controllers.AddTaskController)
-			tasks.GET("/", controllers.GetTaskByProjectController)
-			tasks.PUT("/:task_id", controllers.UpdateTaskController)
+			project.GET("", controllers.GetProjectByIDController)
+			project.PUT("", controllers.EditProjectController)
+			project.DELETE("", controllers.DeleteProjectController)
+			project.POST("/archive", controllers.ArchiveProjectController)
+			project.POST("/unarchive", controllers.UnarchiveProjectController)
+			project.GET("/activity", controllers.GetProjectActivityController)
+
+			project.POST("/collaborators", controllers.AddCollaboratorController)
+			project.PUT("/collaborators", controllers.UpdateCollaboratorRoleController)
+			project.DELETE("/collaborators", controllers.RemoveCollaboratorController)
+
+			project.GET("/workflow", controllers.GetWorkflowController)
+			project.PUT("/workflow", controllers.UpdateWorkflowController)
+
+			project.GET("/schedule", controllers.GetProjectScheduleController)
+			project.GET("/velocity", controllers.GetProjectVelocityController)
+			project.GET("/reports/burndown", controllers.GetBurndownController)
+			project.GET("/reports/cfd", controllers.GetCumulativeFlowController)
+			project.GET("/reports/cycle-time", controllers.GetFlowReportController)
+
+			project.GET("/labels", controllers.GetLabelsController)
+			project.POST("/labels", controllers.AddLabelController)
+			project.DELETE("/labels/:label_id", controllers.DeleteLabelController)
+			project.GET("/time", controllers.GetProjectTimeController)
+			project.GET("/timesheet", controllers.ExportProjectTimesheetController)
+
+			sprints := project.Group("/sprints")
+			{
+				sprints.GET("", controllers.GetSprintsController)
+				sprints.POST("", controllers.AddSprintController)
+				sprints.GET("/:sprint_id", controllers.GetSprintController)
+				sprints.PUT("/:sprint_id", controllers.UpdateSprintController)
+				sprints.DELETE("/:sprint_id", controllers.DeleteSprintController)
+				sprints.POST("/:sprint_id/start", controllers.StartSprintController)
+				sprints.POST("/:sprint_id/close", controllers.CloseSprintController)
+				sprints.POST("/:sprint_id/tasks", controllers.AddSprintTasksController)
+				sprints.DELETE("/:sprint_id/tasks/:task_id", controllers.RemoveSprintTaskController)
+			}
+
+			tasks := project.Group("/tasks")
+			{
+				tasks.POST("/", controllers.AddTaskController)
+				tasks.GET("/", controllers.GetTaskByProjectController)
+				tasks.PUT("/:task_id", controllers.UpdateTaskController)
+				tasks.PATCH("/:task_id", controllers.PatchTaskController)
+				tasks.POST("/:task_id/assignees", controllers.AddTaskAssigneesController)
+				tasks.DELETE("/:task_id/assignees/:user_id", controllers.RemoveTaskAssigneeController)
+			}
 		}
 	}
 }
@@ -61,4 +117,34 @@ func setupTaskRoutes(rg *gin.RouterGroup) {
 	rg.GET("/tasks", controllers.GetAllTaskController)
 	rg.GET("/tasks/:id", controllers.GetTaskByIDController)
 	rg.DELETE("/tasks/:id", controllers.DeleteTaskController)
+	rg.GET("/tasks/:id/history", controllers.GetTaskHistoryController)
+
+	rg.GET("/tasks/:id/comments", controllers.GetTaskCommentsController)
+	rg.POST("/tasks/:id/comments", controllers.AddTaskCommentController)
+	rg.PUT("/tasks/:id/comments/:comment_id", controllers.UpdateTaskCommentController)
+	rg.DELETE("/tasks/:id/comments/:comment_id", controllers.DeleteTaskCommentController)
+	rg.GET("/tasks/:id/comments/:comment_id/history", controllers.GetTaskCommentHistoryController)
+
+	rg.PUT("/tasks/:id/parent", controllers.SetTaskParentController)
+	rg.POST("/tasks/:id/checklist", controllers.AddChecklistItemController)
+	rg.PATCH("/tasks/:id/checklist/:item_id", controllers.UpdateChecklistItemController)
+	rg.DELETE("/tasks/:id/checklist/:item_id", controllers.DeleteChecklistItemController)
+
+	rg.POST("/tasks/:id/dependencies", controllers.AddTaskDependencyController)
+	rg.DELETE("/tasks/:id/dependencies/:dependency_id", controllers.DeleteTaskDependencyController)
+
+	rg.POST("/tasks/:id/labels", controllers.AddTaskLabelController)
+	rg.DELETE("/tasks/:id/labels/:label_id", controllers.RemoveTaskLabelController)
+
+	rg.GET("/tasks/:id/attachments", controllers.GetTaskAttachmentsController)
+	rg.POST("/tasks/:id/attachments", controllers.UploadTaskAttachmentController)
+	rg.GET("/tasks/:id/attachments/:attachment_id", controllers.DownloadTaskAttachmentController)
+	rg.DELETE("/tasks/:id/attachments/:attachment_id", controllers.DeleteTaskAttachmentController)
+
+	rg.POST("/tasks/:id/timer/start", controllers.StartTimerController)
+	rg.POST("/tasks/:id/timer/stop", controllers.StopTimerController)
+	rg.GET("/tasks/:id/time-entries", controllers.GetTaskTimeEntriesController)
+	rg.POST("/tasks/:id/time-entries", controllers.AddTimeEntryController)
+	rg.PUT("/tasks/:id/time-entries/:entry_id", controllers.UpdateTimeEntryController)
+	rg.DELETE("/tasks/:id/time-entries/:entry_id", controllers.DeleteTimeEntryController)
 }
\ No newline at end of file
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// activityFieldNames adalah nama field task yang dipakai di ringkasan activity
+var activityFieldNames = map[string]string{
+	"title":           "judul",
+	"description":     "deskripsi",
+	"status":          "status",
+	"deadline":        "deadline",
+	"duration_days":   "durasi",
+	"story_points":    "story point",
+	"estimated_hours": "estimasi jam",
+	"parent_id":       "parent",
+	"sprint_id":       "sprint",
+	"assigned_to":     "assignee",
+}
+
+// GetProjectActivityService mengembalikan activity feed project (riwayat project dan seluruh task-nya),
+// terbaru dulu. Akses ke project sudah dicek oleh middleware LoadProject. Role yang tidak boleh melihat
+// semua task (guest) hanya mendapat riwayat task yang di-assign ke dirinya.
+func GetProjectActivityService(db *gorm.DB, project *models.Project, userID uint, opts models.ListOptions) ([]models.ActivityItem, string, error) {
+	var assigneeID uint
+	if !policy.Can(userID, policy.ViewAllTasks, project) {
+		assigneeID = userID
+	}
+
+	items, next, err := repository.GetProjectActivity(db, project.ID, assigneeID, opts)
+	if err != nil {
+		return nil, "", listError(err)
+	}
+	for i := range items {
+		if items[i].Type == models.ActivityTask && items[i].TaskTitle == "" {
+			items[i].TaskTitle = deletedTaskTitle(items[i].Changes)
+		}
+		items[i].Summary = activitySummary(items[i])
+	}
+	return items, next, nil
+}
+
+// deletedTaskTitle mengambil judul terakhir task yang sudah dihapus dari isi event-nya
+func deletedTaskTitle(changes models.FieldChanges) string {
+	change, ok := changes["title"]
+	if !ok {
+		return ""
+	}
+	for _, value := range []interface{}{change.After, change.Before} {
+		if title, ok := value.(string); ok && title != "" {
+			return title
+		}
+	}
+	return ""
+}
+
+func activitySummary(item models.ActivityItem) string {
+	actor := "Sistem"
+	if item.Actor != nil {
+		actor = item.Actor.Username
+	}
+	if item.Type == models.ActivityTask {
+		return actor + " " + taskActivitySummary(item)
+	}
+	return actor + " " + projectActivitySummary(item)
+}
+
+func taskActivitySummary(item models.ActivityItem) string {
+	task := fmt.Sprintf("task %q", item.TaskTitle)
+	if item.TaskTitle == "" {
+		task = fmt.Sprintf("task #%d", *item.TaskID)
+	}
+
+	switch item.Action {
+	case models.TaskEventCreated:
+		return "membuat " + task
+	case models.TaskEventDeleted:
+		return "menghapus " + task
+	case models.TaskEventRestored:
+		return "memulihkan " + task + " dari trash"
+	case models.TaskEventAssigned:
+		return "mengganti assignee " + task
+	}
+
+	if status, ok := item.Changes["status"]; ok && len(item.Changes) == 1 {
+		return fmt.Sprintf("mengubah status %s dari %v ke %v", task, status.Before, status.After)
+	}
+	if sprint, ok := item.Changes["sprint_id"]; ok && len(item.Changes) == 1 {
+		if sprint.After == nil {
+			return "memindahkan " + task + " ke backlog"
+		}
+		return fmt.Sprintf("memindahkan %s ke sprint #%v", task, sprint.After)
+	}
+
+	fields := make([]string, 0, len(item.Changes))
+	for field := range item.Changes {
+		name, ok := activityFieldNames[field]
+		if !ok {
+			name = field
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return "mengubah " + strings.Join(fields, ", ") + " pada " + task
+}
+
+func projectActivitySummary(item models.ActivityItem) string {
+	target := "user"
+	if item.TargetUser != nil {
+		target = item.TargetUser.Username
+	}
+
+	switch item.Action {
+	case models.ProjectEventRenamed:
+		name := item.Changes["name"]
+		return fmt.Sprintf("mengganti nama project dari %q menjadi %q", name.Before, name.After)
+	case models.ProjectEventUpdated:
+		return "mengubah deskripsi project"
+	case models.ProjectEventCollaboratorAdded:
+		return fmt.Sprintf("menambahkan %s sebagai %v", target, item.Changes["role"].After)
+	case models.ProjectEventCollaboratorRemoved:
+		if item.Actor != nil && item.TargetUser != nil && item.Actor.ID == item.TargetUser.ID {
+			return "keluar dari project"
+		}
+		return "menghapus " + target + " dari project"
+	case models.ProjectEventRoleChanged:
+		role := item.Changes["role"]
+		return fmt.Sprintf("mengubah role %s dari %v menjadi %v", target, role.Before, role.After)
+	case models.ProjectEventDeleted:
+		return "menghapus project"
+	case models.ProjectEventRestored:
+		return "memulihkan project dari trash"
+	case models.ProjectEventOwnershipTransferred:
+		summary := "memindahkan kepemilikan project ke " + target
+		if role, ok := item.Changes["previous_owner_role"]; ok {
+			summary += fmt.Sprintf(", owner sebelumnya tetap sebagai %v", role.After)
+		}
+		return summary
+	case models.ProjectEventArchived:
+		return "mengarsipkan project"
+	case models.ProjectEventUnarchived:
+		return "mengaktifkan kembali project"
+	case models.ProjectEventDeleteAttempted:
+		if item.Note == models.DeleteAttemptDenied {
+			return "mencoba menghapus project (ditolak)"
+		}
+		return "mencoba menghapus project (data sudah berubah)"
+	}
+	return item.Action
+}
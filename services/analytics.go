@@ -0,0 +1,179 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"math"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// taskFlow adalah lead time dan cycle time satu task dalam jam. hasCycle false jika task tidak pernah
+// tercatat di status berkategori in_progress.
+type taskFlow struct {
+	lead     float64
+	cycle    float64
+	hasCycle bool
+}
+
+// flowSamples mengumpulkan durasi untuk satu kelompok
+type flowSamples struct {
+	id    uint
+	name  string
+	lead  []float64
+	cycle []float64
+}
+
+func (s *flowSamples) add(flow taskFlow) {
+	s.lead = append(s.lead, flow.lead)
+	if flow.hasCycle {
+		s.cycle = append(s.cycle, flow.cycle)
+	}
+}
+
+func (s *flowSamples) group() models.FlowGroup {
+	return models.FlowGroup{ID: s.id, Name: s.name, LeadTime: flowStats(s.lead), CycleTime: flowStats(s.cycle)}
+}
+
+// GetFlowReportService menghitung lead time dan cycle time task yang selesai di [from, to), secara keseluruhan,
+// per assignee dan per label. Waktu selesai diambil dari perpindahan terakhir ke kategori done di riwayat status.
+func GetFlowReportService(db *gorm.DB, project *models.Project, userID uint, from, to time.Time) (models.FlowReport, error) {
+	if err := policy.Authorize(userID, policy.ViewAllTasks, project); err != nil {
+		return models.FlowReport{}, ErrReportDenied
+	}
+	if !from.Before(to) || to.Sub(from) > MaxReportDays*day {
+		return models.FlowReport{}, ErrInvalidReportRange
+	}
+
+	tasks, err := repository.GetCompletedTasks(db, project.ID, from, to)
+	if err != nil {
+		return models.FlowReport{}, err
+	}
+	taskIDs := make([]uint, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+	changes, err := repository.GetStatusChanges(db, project.ID, taskIDs, to)
+	if err != nil {
+		return models.FlowReport{}, err
+	}
+	labels, err := repository.GetLabelsByTask(db, taskIDs)
+	if err != nil {
+		return models.FlowReport{}, err
+	}
+	timelines := buildTimelines(changes)
+
+	overall := &flowSamples{}
+	byAssignee := map[uint]*flowSamples{}
+	byLabel := map[uint]*flowSamples{}
+	for _, task := range tasks {
+		flow := computeTaskFlow(task, timelines[task.ID])
+		overall.add(flow)
+		for _, assignment := range task.Assignments {
+			samples, ok := byAssignee[assignment.UserID]
+			if !ok {
+				samples = &flowSamples{id: assignment.UserID, name: assignment.User.Username}
+				byAssignee[assignment.UserID] = samples
+			}
+			samples.add(flow)
+		}
+		for _, label := range labels[task.ID] {
+			samples, ok := byLabel[label.ID]
+			if !ok {
+				samples = &flowSamples{id: label.ID, name: label.Name}
+				byLabel[label.ID] = samples
+			}
+			samples.add(flow)
+		}
+	}
+
+	report := models.FlowReport{
+		ProjectID:  project.ID,
+		From:       from,
+		To:         to,
+		LeadTime:   flowStats(overall.lead),
+		CycleTime:  flowStats(overall.cycle),
+		ByAssignee: flowGroups(byAssignee),
+		ByLabel:    flowGroups(byLabel),
+	}
+	return report, nil
+}
+
+// computeTaskFlow menghitung durasi dari riwayat status task. Tanpa riwayat, completed_at dipakai sebagai waktu selesai.
+func computeTaskFlow(task models.Task, timeline *statusTimeline) taskFlow {
+	done := time.Time{}
+	if task.CompletedAt != nil {
+		done = *task.CompletedAt
+	}
+	var started *time.Time
+	if timeline != nil {
+		for i, change := range timeline.changes {
+			if change.ToCategory == models.StatusCategoryInProgress && started == nil {
+				started = &timeline.changes[i].ChangedAt
+			}
+			if change.ToCategory == models.StatusCategoryDone {
+				done = change.ChangedAt
+			}
+		}
+	}
+
+	flow := taskFlow{lead: hoursBetween(task.CreatedAt, done)}
+	if started != nil && started.Before(done) {
+		flow.cycle = hoursBetween(*started, done)
+		flow.hasCycle = true
+	}
+	return flow
+}
+
+func hoursBetween(from, to time.Time) float64 {
+	if to.Before(from) {
+		return 0
+	}
+	return to.Sub(from).Hours()
+}
+
+// flowStats menghitung rata-rata dan persentil (nearest rank) dari durasi dalam jam
+func flowStats(values []float64) models.FlowStats {
+	stats := models.FlowStats{Count: len(values)}
+	if len(values) == 0 {
+		return stats
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, value := range sorted {
+		sum += value
+	}
+	stats.Average = round2(sum / float64(len(sorted)))
+	stats.P50 = round2(percentile(sorted, 50))
+	stats.P85 = round2(percentile(sorted, 85))
+	stats.P95 = round2(percentile(sorted, 95))
+	return stats
+}
+
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// flowGroups mengurutkan kelompok dari cycle time median terlama sehingga bottleneck muncul di atas
+func flowGroups(groups map[uint]*flowSamples) []models.FlowGroup {
+	result := make([]models.FlowGroup, 0, len(groups))
+	for _, samples := range groups {
+		result = append(result, samples.group())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CycleTime.P50 != result[j].CycleTime.P50 {
+			return result[i].CycleTime.P50 > result[j].CycleTime.P50
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"PA/storage"
+	"PA/utils"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Batas attachment, dapat diatur lewat ATTACHMENT_MAX_SIZE (byte) dan ATTACHMENT_ALLOWED_TYPES (dipisahkan koma)
+var (
+	MaxAttachmentSize      = utils.Int64FromEnv("ATTACHMENT_MAX_SIZE", 10<<20)
+	AllowedAttachmentTypes = utils.ListFromEnv("ATTACHMENT_ALLOWED_TYPES", []string{
+		"image/png", "image/jpeg", "image/gif", "image/webp",
+		"application/pdf", "application/zip", "text/plain", "text/csv",
+	})
+)
+
+// BlobGracePeriod adalah waktu tunggu sebelum blob tanpa attachment dihapus dari storage,
+// supaya upload yang sedang memakai blob yang sama tidak kehilangan file
+const BlobGracePeriod = 24 * time.Hour
+
+func blobKey(checksum string) string {
+	return "blobs/" + checksum[:2] + "/" + checksum
+}
+
+func isAllowedType(contentType string) bool {
+	for _, allowed := range AllowedAttachmentTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+func cleanFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+func GetTaskAttachmentsService(db *gorm.DB, taskID, userID uint) ([]models.Attachment, error) {
+	if _, err := getReadableTask(db, taskID, userID); err != nil {
+		return nil, err
+	}
+	return repository.GetTaskAttachments(db, taskID)
+}
+
+// UploadAttachmentService menyimpan file ke file sementara sambil menghitung sha256 dan ukurannya,
+// mengecek batas ukuran dan tipe MIME (dari isi file, bukan dari nama), lalu menyimpan ke storage
+// hanya jika file dengan checksum yang sama belum pernah diupload.
+func UploadAttachmentService(db *gorm.DB, store storage.Store, taskID uint, fileName string, file io.Reader, userID uint) (models.Attachment, error) {
+	task, err := getTask(db, taskID)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	if !policy.Can(userID, policy.UpdateTask, &task) {
+		return models.Attachment{}, ErrAttachmentDenied
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return models.Attachment{}, err
+	}
+
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(file, MaxAttachmentSize+1))
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	if size > MaxAttachmentSize {
+		return models.Attachment{}, ErrAttachmentTooLarge
+	}
+	if size == 0 {
+		return models.Attachment{}, ErrEmptyAttachment
+	}
+
+	head := make([]byte, 512)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return models.Attachment{}, err
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head[:n]))
+	if !isAllowedType(contentType) {
+		return models.Attachment{}, ErrAttachmentType
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	exists, err := repository.TouchBlob(db, checksum)
+	if err != nil {
+		return models.Attachment{}, err
+	}
+	if !exists {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return models.Attachment{}, err
+		}
+		if err := store.Put(blobKey(checksum), tmp, size, contentType); err != nil {
+			return models.Attachment{}, err
+		}
+	}
+
+	attachment := models.Attachment{
+		TaskID:      task.ID,
+		UploaderID:  userID,
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    checksum,
+	}
+	if err := repository.CreateAttachment(db, &attachment); err != nil {
+		return models.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+func getAttachment(db *gorm.DB, taskID, attachmentID uint) (models.Attachment, error) {
+	attachment, err := repository.GetAttachment(db, taskID, attachmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Attachment{}, ErrAttachmentNotFound
+		}
+		return models.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// OpenAttachmentService membuka isi attachment untuk di-stream ke client. Pemanggil wajib menutup reader.
+func OpenAttachmentService(db *gorm.DB, store storage.Store, taskID, attachmentID, userID uint) (models.Attachment, io.ReadCloser, error) {
+	if _, err := getReadableTask(db, taskID, userID); err != nil {
+		return models.Attachment{}, nil, err
+	}
+	attachment, err := getAttachment(db, taskID, attachmentID)
+	if err != nil {
+		return models.Attachment{}, nil, err
+	}
+
+	reader, err := store.Get(blobKey(attachment.Checksum))
+	if errors.Is(err, storage.ErrNotFound) {
+		return models.Attachment{}, nil, ErrAttachmentNotFound
+	}
+	return attachment, reader, err
+}
+
+// DeleteAttachmentService menghapus attachment oleh pengupload atau user yang boleh menghapus task.
+// Isi file di storage dihapus belakangan oleh PurgeOrphanBlobs.
+func DeleteAttachmentService(db *gorm.DB, taskID, attachmentID, userID uint) error {
+	task, err := getReadableTask(db, taskID, userID)
+	if err != nil {
+		return err
+	}
+	attachment, err := getAttachment(db, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if attachment.UploaderID != userID && !policy.Can(userID, policy.DeleteTask, &task) {
+		return ErrAttachmentDenied
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return err
+	}
+	return repository.DeleteAttachment(db, attachment.ID)
+}
+
+// PurgeOrphanBlobs menghapus file di storage yang sudah tidak dipakai attachment mana pun
+func PurgeOrphanBlobs(db *gorm.DB, store storage.Store) error {
+	cutoff := time.Now().Add(-BlobGracePeriod)
+	checksums, err := repository.GetOrphanBlobs(db, cutoff)
+	if err != nil {
+		return err
+	}
+
+	for _, checksum := range checksums {
+		key := blobKey(checksum)
+		err := repository.DeleteOrphanBlob(db, checksum, cutoff, func() error {
+			return store.Delete(key)
+		})
+		if err != nil {
+			log.Printf("gagal menghapus blob %s: %v", checksum, err)
+		}
+	}
+	return nil
+}
@@ -6,11 +6,29 @@ import (
 	"PA/repository"
 	"PA/utils"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
 
-func LoginService(db *gorm.DB, identifier, password string) (string, error) {
+// SystemAdmins adalah username atau email admin sistem dari ADMIN_USERS (dipisahkan koma)
+var SystemAdmins = utils.ListFromEnv("ADMIN_USERS", []string{})
+
+func isSystemAdmin(username, email string) bool {
+	for _, identifier := range SystemAdmins {
+		if identifier == username || (email != "" && identifier == email) {
+			return true
+		}
+	}
+	return false
+}
+
+// SyncSystemAdmins menyamakan status admin sistem di database dengan ADMIN_USERS, dijalankan saat startup
+func SyncSystemAdmins(db *gorm.DB) error {
+	return repository.SyncAdmins(db, SystemAdmins)
+}
+
+func LoginService(db *gorm.DB, identifier, password string) (models.AuthTokens, error) {
 	var user models.User
 	var err error
 	if strings.Contains(identifier, "@") {
@@ -23,39 +41,182 @@ func LoginService(db *gorm.DB, identifier, password string) (string, error) {
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return "", errors.New("user tidak ditemukan")
+			return models.AuthTokens{}, ErrInvalidCredentials
 		}
-		return "", err
+		return models.AuthTokens{}, err
 	}
 
 	if !utils.CheckPassword(password, user.Password) {
-        return "", errors.New("invalid password")
+        return models.AuthTokens{}, ErrInvalidCredentials
     }
 
-	token, err := utils.GenerateJWT(user)
+	familyID, err := utils.GenerateTokenFamily()
 	if err != nil {
-		return "", errors.New("gagal generate token")
+		return models.AuthTokens{}, errors.New("gagal generate token")
+	}
+
+	refreshToken, err := createRefreshToken(db, user.ID, familyID)
+	if err != nil {
+		return models.AuthTokens{}, errors.New("gagal generate token")
+	}
+
+	return issueTokens(user, familyID, refreshToken)
+}
+
+// createRefreshToken menyimpan hash refresh token baru di dalam family yang sama
+func createRefreshToken(db *gorm.DB, userID uint, familyID string) (string, error) {
+	token, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", err
 	}
 
+	record := models.RefreshToken{
+		UserID:    userID,
+		TokenHash: hash,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL),
+	}
+	if err := repository.CreateRefreshToken(db, &record); err != nil {
+		return "", err
+	}
 	return token, nil
 }
 
+func issueTokens(user models.User, familyID, refreshToken string) (models.AuthTokens, error) {
+	accessToken, _, err := utils.GenerateJWT(user, familyID)
+	if err != nil {
+		return models.AuthTokens{}, errors.New("gagal generate token")
+	}
+
+	return models.AuthTokens{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(utils.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func RefreshTokenService(db *gorm.DB, refreshToken string) (models.AuthTokens, error) {
+	current, err := repository.GetRefreshTokenByHash(db, utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.AuthTokens{}, ErrInvalidRefreshToken
+		}
+		return models.AuthTokens{}, err
+	}
+
+	if current.RevokedAt != nil {
+		return models.AuthTokens{}, rejectRevokedToken(db, current)
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return models.AuthTokens{}, ErrRefreshTokenExpired
+	}
+
+	user, err := repository.GetUserByID(db, current.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.AuthTokens{}, ErrInvalidRefreshToken
+		}
+		return models.AuthTokens{}, err
+	}
+
+	token, hash, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return models.AuthTokens{}, errors.New("gagal generate token")
+	}
+	next := models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		FamilyID:  current.FamilyID,
+		ExpiresAt: time.Now().Add(utils.RefreshTokenTTL),
+	}
+
+	rotated, err := repository.RotateRefreshToken(db, current.ID, &next)
+	if err != nil {
+		return models.AuthTokens{}, err
+	}
+	if !rotated {
+		// Token dicabut bersamaan dengan refresh ini, baik oleh refresh paralel maupun oleh logout
+		current, err = repository.GetRefreshTokenByHash(db, current.TokenHash)
+		if err != nil {
+			return models.AuthTokens{}, err
+		}
+		return models.AuthTokens{}, rejectRevokedToken(db, current)
+	}
+
+	return issueTokens(user, current.FamilyID, token)
+}
+
+// rejectRevokedToken menentukan error untuk refresh token yang sudah dicabut. Token yang sudah dirotasi
+// lalu dipakai lagi dianggap bocor, sehingga seluruh family-nya dicabut; token yang dicabut lewat logout
+// hanya ditolak tanpa mengganggu sesi lain.
+func rejectRevokedToken(db *gorm.DB, current models.RefreshToken) error {
+	if current.ReplacedByID == nil {
+		return ErrInvalidRefreshToken
+	}
+	if err := repository.RevokeTokenFamily(db, current.FamilyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// LogoutService mencabut access token yang sedang dipakai beserta family refresh token-nya.
+// Jika all bernilai true, semua refresh token milik user dan semua access token yang sudah terbit ikut dicabut.
+func LogoutService(db *gorm.DB, userID uint, session utils.TokenClaims, all bool) error {
+	if err := repository.RevokeAccessToken(db, session.ID, userID, session.ExpiresAt); err != nil {
+		return err
+	}
+
+	if all {
+		if err := repository.RevokeUserRefreshTokens(db, userID); err != nil {
+			return err
+		}
+		return repository.RevokeUserAccessTokens(db, userID, time.Now())
+	}
+	if session.FamilyID != "" {
+		return repository.RevokeTokenFamily(db, session.FamilyID)
+	}
+	return nil
+}
+
+// PurgeExpiredTokens menghapus refresh token dan catatan access token yang dicabut setelah kadaluarsa,
+// dijalankan berkala dari main
+func PurgeExpiredTokens(db *gorm.DB) error {
+	return repository.PurgeExpiredTokens(db)
+}
+
 func RegisterService(db *gorm.DB, input models.UserAuth) error {
     if input.Email != "" && !utils.IsValidEmail(input.Email) {
-        return errors.New("invalid email format")
+        return ErrInvalidEmail
     }
 
     if strings.Contains(input.Username, "@") {
-        return errors.New("username cannot contain '@'")
+        return ErrInvalidUsername
     }
 
     if !utils.IsValidPassword(input.Password) {
-        return errors.New("invalid password format")
+        return ErrInvalidPassword
+    }
+
+    if input.Username != "" {
+        if _, err := repository.GetUserByUsername(db, input.Username); err == nil {
+            return ErrUsernameTaken
+        } else if !errors.Is(err, gorm.ErrRecordNotFound) {
+            return err
+        }
+    }
+    if input.Email != "" {
+        if _, err := repository.GetUserByEmail(db, input.Email); err == nil {
+            return ErrEmailTaken
+        } else if !errors.Is(err, gorm.ErrRecordNotFound) {
+            return err
+        }
     }
 
     user := models.User{
         Username: input.Username,
         Email:    input.Email,
+        IsAdmin:  isSystemAdmin(input.Username, input.Email),
     }
 
     hashedPass, err := utils.HashPassword(input.Password)
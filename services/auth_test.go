@@ -0,0 +1,93 @@
+package services
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"PA/internal/fakedb"
+)
+
+// refreshTokenRow membuat baris tabel refresh_tokens milik user 1 di family "fam-1"
+func refreshTokenRow(revokedAt, replacedByID interface{}) fakedb.Result {
+	return fakedb.Result{
+		Columns: []string{"id", "user_id", "token_hash", "family_id", "expires_at", "revoked_at", "replaced_by_id"},
+		Rows:    [][]driver.Value{{int64(11), int64(1), "hash", "fam-1", time.Now().Add(time.Hour), revokedAt, replacedByID}},
+	}
+}
+
+func TestRefreshTokenServiceRevokedToken(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name          string
+		tokens        []fakedb.Result // hasil select refresh_tokens berurutan
+		want          error
+		familyRevoked bool
+	}{
+		{
+			name:   "refresh setelah logout",
+			tokens: []fakedb.Result{refreshTokenRow(revokedAt, nil)},
+			want:   ErrInvalidRefreshToken,
+		},
+		{
+			name:          "refresh token hasil rotasi dipakai ulang",
+			tokens:        []fakedb.Result{refreshTokenRow(revokedAt, int64(12))},
+			want:          ErrRefreshTokenReused,
+			familyRevoked: true,
+		},
+		{
+			name:   "logout bersamaan dengan refresh",
+			tokens: []fakedb.Result{refreshTokenRow(nil, nil), refreshTokenRow(revokedAt, nil)},
+			want:   ErrInvalidRefreshToken,
+		},
+		{
+			name:          "refresh paralel dengan token yang sama",
+			tokens:        []fakedb.Result{refreshTokenRow(nil, nil), refreshTokenRow(revokedAt, int64(12))},
+			want:          ErrRefreshTokenReused,
+			familyRevoked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selects := 0
+			db, fake := fakedb.Open(t, func(query string, _ []driver.NamedValue) fakedb.Result {
+				switch {
+				case strings.HasPrefix(query, `SELECT * FROM "refresh_tokens"`):
+					selects++
+					return tt.tokens[selects-1]
+				case strings.HasPrefix(query, `SELECT * FROM "users"`):
+					return fakedb.Result{Columns: []string{"id", "username"}, Rows: [][]driver.Value{{int64(1), "alice"}}}
+				case strings.HasPrefix(query, `INSERT INTO "refresh_tokens"`):
+					return fakedb.Result{Columns: []string{"id"}, Rows: [][]driver.Value{{int64(13)}}}
+				}
+				// token lama sudah dicabut lebih dulu, jadi rotasi tidak mengubah baris apa pun
+				return fakedb.Result{}
+			})
+
+			_, err := RefreshTokenService(db, "token")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RefreshTokenService() error = %v, want %v", err, tt.want)
+			}
+			if selects != len(tt.tokens) {
+				t.Errorf("select refresh_tokens = %d, want %d", selects, len(tt.tokens))
+			}
+
+			var familyRevoked bool
+			for _, update := range fake.Queries(`UPDATE "refresh_tokens"`) {
+				if strings.Contains(update.SQL, "family_id") {
+					familyRevoked = true
+				}
+			}
+			if familyRevoked != tt.familyRevoked {
+				t.Errorf("family dicabut = %v, want %v", familyRevoked, tt.familyRevoked)
+			}
+			if updates := fake.Queries(`UPDATE "users"`); len(updates) != 0 {
+				t.Errorf("access token user lain tidak boleh ikut dicabut: %v", updates)
+			}
+		})
+	}
+}
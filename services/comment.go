@@ -0,0 +1,180 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"errors"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// mentionPattern mencocokkan @username yang diawali spasi/awal teks, sehingga alamat email tidak dianggap mention
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w])@([^\s@]+)`)
+
+// parseMentions mengambil daftar username unik (huruf kecil) dari isi comment
+func parseMentions(body string) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		name := strings.ToLower(strings.TrimRight(match[1], ".,!?:;)\"'"))
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// resolveMentions mencocokkan @username dengan anggota project (owner dan collaborator).
+// Username yang bukan anggota project diabaikan.
+func resolveMentions(db *gorm.DB, projectID uint, body string) ([]uint, error) {
+	names := parseMentions(body)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	members, err := repository.GetProjectMembers(db, projectID)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]uint, len(members))
+	for _, member := range members {
+		byName[strings.ToLower(member.Username)] = member.ID
+	}
+
+	userIDs := []uint{}
+	for _, name := range names {
+		if id, ok := byName[name]; ok {
+			userIDs = append(userIDs, id)
+		}
+	}
+	return userIDs, nil
+}
+
+// getReadableTask memuat task dan memastikan user boleh membacanya. Comment mengikuti hak baca task.
+func getReadableTask(db *gorm.DB, taskID, userID uint) (models.Task, error) {
+	task, err := getTask(db, taskID)
+	if err != nil {
+		return models.Task{}, err
+	}
+	if !policy.Can(userID, policy.ViewTask, &task) {
+		return models.Task{}, ErrTaskAccessDenied
+	}
+	return task, nil
+}
+
+func getComment(db *gorm.DB, taskID, commentID uint) (models.TaskComment, error) {
+	comment, err := repository.GetCommentByID(db, taskID, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.TaskComment{}, ErrCommentNotFound
+		}
+		return models.TaskComment{}, err
+	}
+	return comment, nil
+}
+
+func GetTaskCommentsService(db *gorm.DB, taskID, userID uint, opts models.ListOptions) ([]models.TaskComment, string, error) {
+	if _, err := getReadableTask(db, taskID, userID); err != nil {
+		return nil, "", err
+	}
+	comments, next, err := repository.GetTaskComments(db, taskID, opts)
+	return comments, next, listError(err)
+}
+
+func CreateCommentService(db *gorm.DB, taskID uint, body string, userID uint) (models.TaskComment, error) {
+	task, err := getReadableTask(db, taskID, userID)
+	if err != nil {
+		return models.TaskComment{}, err
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return models.TaskComment{}, err
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return models.TaskComment{}, ErrEmptyComment
+	}
+	mentionIDs, err := resolveMentions(db, task.ProjectID, body)
+	if err != nil {
+		return models.TaskComment{}, err
+	}
+
+	comment := models.TaskComment{
+		TaskID:   task.ID,
+		AuthorID: userID,
+		Body:     body,
+	}
+	if err := repository.CreateComment(db, &comment, mentionIDs); err != nil {
+		return models.TaskComment{}, err
+	}
+	return comment, nil
+}
+
+// UpdateCommentService mengubah isi comment. Hanya penulis yang boleh mengedit, isi lama disimpan ke riwayat.
+func UpdateCommentService(db *gorm.DB, taskID, commentID uint, body string, userID uint) (models.TaskComment, error) {
+	task, err := getReadableTask(db, taskID, userID)
+	if err != nil {
+		return models.TaskComment{}, err
+	}
+	comment, err := getComment(db, taskID, commentID)
+	if err != nil {
+		return models.TaskComment{}, err
+	}
+	if comment.AuthorID != userID {
+		return models.TaskComment{}, ErrCommentEditDenied
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return models.TaskComment{}, err
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return models.TaskComment{}, ErrEmptyComment
+	}
+	if body == comment.Body {
+		return comment, nil
+	}
+	mentionIDs, err := resolveMentions(db, task.ProjectID, body)
+	if err != nil {
+		return models.TaskComment{}, err
+	}
+
+	if err := repository.UpdateComment(db, &comment, body, userID, mentionIDs); err != nil {
+		return models.TaskComment{}, err
+	}
+	return comment, nil
+}
+
+// DeleteCommentService menghapus (soft delete) comment milik sendiri, owner/admin boleh menghapus comment siapa saja
+func DeleteCommentService(db *gorm.DB, taskID, commentID uint, userID uint) error {
+	task, err := getReadableTask(db, taskID, userID)
+	if err != nil {
+		return err
+	}
+	comment, err := getComment(db, taskID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != userID && !policy.Can(userID, policy.ModerateComments, &task) {
+		return ErrCommentDeleteDenied
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return err
+	}
+	return repository.DeleteComment(db, comment.ID, userID)
+}
+
+func GetCommentHistoryService(db *gorm.DB, taskID, commentID uint, userID uint) ([]models.TaskCommentEdit, error) {
+	if _, err := getReadableTask(db, taskID, userID); err != nil {
+		return nil, err
+	}
+	if _, err := getComment(db, taskID, commentID); err != nil {
+		return nil, err
+	}
+	return repository.GetCommentEdits(db, commentID)
+}
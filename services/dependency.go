@@ -0,0 +1,131 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// AddDependencyService membuat link antara task di url dan otherID. linkType "blocks" berarti task di url
+// memblokir otherID, "blocked_by" berarti task di url diblokir oleh otherID. User harus boleh mengubah
+// task yang diblokir dan boleh melihat task blocker.
+func AddDependencyService(db *gorm.DB, taskID uint, linkType string, otherID uint, userID uint) (models.TaskDependency, error) {
+	task, err := getTask(db, taskID)
+	if err != nil {
+		return models.TaskDependency{}, err
+	}
+	other, err := getTask(db, otherID)
+	if err != nil {
+		if errors.Is(err, ErrTaskNotFound) {
+			return models.TaskDependency{}, ErrLinkedTaskNotFound
+		}
+		return models.TaskDependency{}, err
+	}
+
+	var blocker, blocked models.Task
+	switch linkType {
+	case models.LinkBlocks:
+		blocker, blocked = task, other
+	case models.LinkBlockedBy:
+		blocker, blocked = other, task
+	default:
+		return models.TaskDependency{}, ErrInvalidDependencyType
+	}
+
+	if !policy.Can(userID, policy.UpdateTask, &blocked) || !policy.Can(userID, policy.ViewTask, &blocker) {
+		return models.TaskDependency{}, ErrDependencyDenied
+	}
+	if err := checkWritable(&blocked.Project); err != nil {
+		return models.TaskDependency{}, err
+	}
+	if blocker.ID == blocked.ID {
+		return models.TaskDependency{}, ErrDependencyCycle
+	}
+
+	dependency := models.TaskDependency{
+		BlockerID:   blocker.ID,
+		BlockedID:   blocked.ID,
+		CreatedByID: userID,
+	}
+	err = repository.CreateDependency(db, &dependency)
+	switch {
+	case errors.Is(err, repository.ErrDependencyExists):
+		return models.TaskDependency{}, ErrDependencyExists
+	case errors.Is(err, repository.ErrDependencyCycle):
+		return models.TaskDependency{}, ErrDependencyCycle
+	case err != nil:
+		return models.TaskDependency{}, err
+	}
+	return dependency, nil
+}
+
+func DeleteDependencyService(db *gorm.DB, taskID, dependencyID uint, userID uint) error {
+	dependency, err := repository.GetDependency(db, taskID, dependencyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDependencyNotFound
+		}
+		return err
+	}
+	blocked, err := getTask(db, dependency.BlockedID)
+	if err != nil {
+		return err
+	}
+	if !policy.Can(userID, policy.UpdateTask, &blocked) {
+		return ErrDependencyDenied
+	}
+	if err := checkWritable(&blocked.Project); err != nil {
+		return err
+	}
+	return repository.DeleteDependency(db, dependency.ID)
+}
+
+// taskLink membuat TaskLink ke task lain, detail task hanya diisi jika user boleh melihatnya
+func taskLink(dependencyID uint, task models.Task, userID uint) models.TaskLink {
+	link := models.TaskLink{DependencyID: dependencyID, TaskID: task.ID}
+	if policy.Can(userID, policy.ViewTask, &task) {
+		link.ProjectID = task.ProjectID
+		link.Title = task.Title
+		link.Status = task.Status
+	}
+	return link
+}
+
+// loadTaskLinks mengisi kedua arah dependency task
+func loadTaskLinks(db *gorm.DB, task *models.Task, userID uint) error {
+	blocks, blockedBy, err := repository.GetTaskDependencies(db, task.ID)
+	if err != nil {
+		return err
+	}
+
+	task.Blocks = make([]models.TaskLink, len(blocks))
+	for i, dependency := range blocks {
+		task.Blocks[i] = taskLink(dependency.ID, dependency.Blocked, userID)
+	}
+	task.BlockedBy = make([]models.TaskLink, len(blockedBy))
+	for i, dependency := range blockedBy {
+		task.BlockedBy[i] = taskLink(dependency.ID, dependency.Blocker, userID)
+	}
+	return nil
+}
+
+// checkBlockers menolak perpindahan ke status done selama masih ada blocker yang belum selesai.
+// Daftar blocker dikirim di details.blocking_tasks.
+func checkBlockers(db *gorm.DB, taskID uint, userID uint) error {
+	open, err := repository.GetOpenBlockers(db, taskID)
+	if err != nil {
+		return err
+	}
+	if len(open) == 0 {
+		return nil
+	}
+
+	links := make([]models.TaskLink, len(open))
+	for i, dependency := range open {
+		links[i] = taskLink(dependency.ID, dependency.Blocker, userID)
+	}
+	return ErrTaskBlocked.WithDetail("blocking_tasks", links)
+}
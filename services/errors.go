@@ -0,0 +1,168 @@
+package services
+
+import (
+	"PA/repository"
+	"PA/utils"
+	"errors"
+)
+
+// Error domain yang dikembalikan service. Controller cukup meneruskannya ke c.Error,
+// status HTTP dan body problem+json ditentukan oleh middleware.ErrorHandler.
+var (
+	ErrInvalidCredentials  = utils.Unauthorized("invalid_credentials", "username/email atau password salah")
+	ErrInvalidRefreshToken = utils.Unauthorized("invalid_refresh_token", "refresh token tidak valid")
+	ErrRefreshTokenReused  = utils.Unauthorized("refresh_token_reused", "refresh token sudah digunakan, silakan login kembali")
+	ErrRefreshTokenExpired = utils.Unauthorized("refresh_token_expired", "refresh token sudah kadaluarsa")
+	ErrInvalidEmail        = utils.Validation("invalid_email", "invalid email format")
+	ErrInvalidUsername     = utils.Validation("invalid_username", "username cannot contain '@'")
+	ErrInvalidPassword     = utils.Validation("invalid_password_format", "invalid password format")
+	ErrUsernameTaken       = utils.Conflict("username_taken", "username sudah digunakan")
+	ErrEmailTaken          = utils.Conflict("email_taken", "email sudah digunakan")
+	ErrUserNotFound        = utils.NotFound("user_not_found", "user tidak ditemukan")
+
+	ErrProjectNotFound      = utils.NotFound("project_not_found", "project tidak ditemukan")
+	ErrProjectAccessDenied  = utils.Forbidden("project_access_denied", "anda tidak memiliki akses ke project ini")
+	ErrProjectUpdateDenied  = utils.Forbidden("project_update_forbidden", "hanya owner/admin yang bisa mengupdate project")
+	ErrProjectDeleteDenied  = utils.Forbidden("project_delete_forbidden", "hanya owner yang bisa menghapus project")
+	ErrCollaboratorDenied   = utils.Forbidden("collaborator_manage_forbidden", "hanya owner/admin yang bisa mengelola collaborator")
+	ErrCollaboratorNotFound = utils.NotFound("collaborator_not_found", "collaborator tidak ditemukan di project ini")
+	ErrAlreadyMember        = utils.Conflict("already_member", "user sudah menjadi anggota project")
+	ErrInvalidRole          = utils.Validation("invalid_role", "role tidak valid")
+	ErrOwnerRoleImmutable   = utils.Validation("owner_role_immutable", "role owner tidak dapat diubah")
+	ErrProjectArchived      = utils.Conflict("project_archived", "project sudah diarsipkan dan hanya bisa dibaca, aktifkan kembali project untuk mengubahnya")
+	ErrArchiveDenied        = utils.Forbidden("project_archive_forbidden", "hanya owner yang bisa mengarsipkan atau mengaktifkan kembali project")
+	ErrTransferDenied       = utils.Forbidden("ownership_transfer_forbidden", "hanya owner atau admin sistem yang bisa memindahkan kepemilikan project")
+	ErrAlreadyOwner         = utils.Validation("already_owner", "user tersebut sudah menjadi owner project")
+
+	ErrTaskNotFound      = utils.NotFound("task_not_found", "task tidak ditemukan")
+	ErrTaskAccessDenied  = utils.Forbidden("task_access_denied", "anda tidak memiliki akses ke task ini")
+	ErrTaskCreateDenied  = utils.Forbidden("task_create_forbidden", "anda tidak memiliki izin untuk membuat task di project ini")
+	ErrTaskUpdateDenied  = utils.Forbidden("task_update_forbidden", "anda tidak memiliki izin untuk mengubah task ini")
+	ErrTaskDeleteDenied  = utils.Forbidden("task_delete_forbidden", "hanya owner/admin yang bisa menghapus task")
+	ErrInvalidAssignment = utils.Validation("invalid_assignee", "user yang di-assign harus anggota project")
+	ErrAssigneeNotFound  = utils.NotFound("assignee_not_found", "user tidak di-assign ke task ini")
+	ErrInvalidDuration   = utils.Validation("invalid_duration_days", "duration_days tidak boleh negatif")
+	ErrInvalidEstimate   = utils.Validation("invalid_estimate", "story_points dan estimated_hours tidak boleh negatif")
+)
+
+var (
+	ErrParentNotFound        = utils.NotFound("parent_not_found", "parent task tidak ditemukan")
+	ErrInvalidParent         = utils.Validation("invalid_parent", "parent task harus berada di project yang sama")
+	ErrTaskCycle             = utils.Validation("task_cycle", "task tidak boleh menjadi subtask dari dirinya sendiri atau turunannya")
+	ErrTaskTooDeep           = utils.Validation("task_too_deep", "hierarki subtask melebihi batas kedalaman")
+	ErrChecklistItemNotFound = utils.NotFound("checklist_item_not_found", "item checklist tidak ditemukan")
+	ErrEmptyChecklistTitle   = utils.Validation("empty_checklist_title", "judul item checklist tidak boleh kosong")
+)
+
+var (
+	ErrLinkedTaskNotFound    = utils.NotFound("linked_task_not_found", "task yang akan di-link tidak ditemukan")
+	ErrDependencyNotFound    = utils.NotFound("dependency_not_found", "dependency tidak ditemukan")
+	ErrInvalidDependencyType = utils.Validation("invalid_dependency_type", "type harus blocks atau blocked_by")
+	ErrDependencyExists      = utils.Conflict("dependency_exists", "dependency sudah ada")
+	ErrDependencyCycle       = utils.Conflict("dependency_cycle", "dependency akan membentuk siklus")
+	ErrDependencyDenied      = utils.Forbidden("dependency_forbidden", "anda tidak memiliki izin untuk mengubah dependency task ini")
+	ErrTaskBlocked           = utils.Conflict("task_blocked", "task masih diblokir oleh task lain yang belum selesai")
+)
+
+var (
+	ErrAttachmentNotFound = utils.NotFound("attachment_not_found", "attachment tidak ditemukan")
+	ErrAttachmentDenied   = utils.Forbidden("attachment_forbidden", "anda tidak memiliki izin untuk mengelola attachment task ini")
+	ErrAttachmentTooLarge = utils.Validation("attachment_too_large", "ukuran file melebihi batas")
+	ErrAttachmentType     = utils.Validation("attachment_type_not_allowed", "tipe file tidak diizinkan")
+	ErrEmptyAttachment    = utils.Validation("empty_attachment", "file kosong")
+)
+
+var (
+	ErrLabelNotFound     = utils.NotFound("label_not_found", "label tidak ditemukan")
+	ErrLabelManageDenied = utils.Forbidden("label_manage_forbidden", "anda tidak memiliki izin untuk mengelola label di project ini")
+	ErrInvalidLabel      = utils.Validation("invalid_label", "nama label tidak boleh kosong")
+	ErrLabelExists       = utils.Conflict("label_exists", "label dengan nama tersebut sudah ada")
+)
+
+var (
+	ErrSprintNotFound      = utils.NotFound("sprint_not_found", "sprint tidak ditemukan")
+	ErrSprintManageDenied  = utils.Forbidden("sprint_manage_forbidden", "hanya owner/admin yang bisa mengelola sprint")
+	ErrInvalidSprint       = utils.Validation("invalid_sprint", "name wajib diisi dan end_date harus setelah start_date")
+	ErrInvalidNextSprint   = utils.Validation("invalid_next_sprint", "sprint tujuan harus sprint lain di project yang sama yang belum ditutup")
+	ErrSprintClosed        = utils.Conflict("sprint_closed", "sprint sudah ditutup dan tidak dapat diubah")
+	ErrSprintNotPlanned    = utils.Conflict("sprint_not_planned", "hanya sprint berstatus planned yang dapat dimulai atau dihapus")
+	ErrSprintNotActive     = utils.Conflict("sprint_not_active", "hanya sprint yang sedang active yang dapat ditutup")
+	ErrSprintAlreadyActive = utils.Conflict("sprint_already_active", "project sudah memiliki sprint yang active")
+	ErrTaskInClosedSprint  = utils.Conflict("task_in_closed_sprint", "task sudah tercatat di sprint yang ditutup")
+	ErrTaskNotInSprint     = utils.NotFound("task_not_in_sprint", "task tidak ada di sprint ini")
+)
+
+var (
+	ErrTimeEntryNotFound     = utils.NotFound("time_entry_not_found", "time entry tidak ditemukan")
+	ErrTimeTrackingDenied    = utils.Forbidden("time_tracking_forbidden", "hanya assignee atau anggota project yang bisa mencatat waktu pada task ini")
+	ErrTimerRunning          = utils.Conflict("timer_already_running", "masih ada timer yang berjalan, hentikan terlebih dahulu")
+	ErrNoRunningTimer        = utils.Conflict("no_running_timer", "tidak ada timer yang berjalan pada task ini")
+	ErrInvalidTimeEntry      = utils.Validation("invalid_time_entry", "started_at wajib diisi dan ended_at harus setelah started_at atau duration_minutes lebih dari 0")
+	ErrInvalidTimesheetGroup = utils.Validation("invalid_group_by", "group_by harus salah satu dari day, task, user")
+)
+
+var (
+	ErrCommentNotFound     = utils.NotFound("comment_not_found", "comment tidak ditemukan")
+	ErrCommentEditDenied   = utils.Forbidden("comment_edit_forbidden", "hanya penulis yang bisa mengedit comment")
+	ErrCommentDeleteDenied = utils.Forbidden("comment_delete_forbidden", "hanya penulis atau owner/admin yang bisa menghapus comment")
+	ErrEmptyComment        = utils.Validation("empty_comment", "isi comment tidak boleh kosong")
+)
+
+var (
+	ErrTrashRestoreDenied  = utils.Forbidden("trash_restore_forbidden", "hanya user yang boleh menghapus item ini yang bisa memulihkannya")
+	ErrRestoreProjectFirst = utils.Conflict("project_in_trash", "project task ini ada di trash, pulihkan project terlebih dahulu")
+	ErrRestoreParentFirst  = utils.Conflict("parent_in_trash", "parent task ini ada di trash, pulihkan parent terlebih dahulu")
+)
+
+var (
+	ErrUnknownStatus     = utils.Validation("unknown_status", "status tidak dikenal di workflow project ini")
+	ErrIllegalTransition = utils.Conflict("illegal_status_transition", "perpindahan status tidak diizinkan oleh workflow project")
+	ErrInvalidWorkflow   = utils.Validation("invalid_workflow", "workflow tidak valid")
+	ErrStatusInUse       = utils.Conflict("status_in_use", "status masih dipakai oleh task dan tidak bisa dihapus")
+	ErrWorkflowDenied    = utils.Forbidden("workflow_manage_forbidden", "hanya owner/admin yang bisa mengubah workflow")
+)
+
+var (
+	ErrReportDenied         = utils.Forbidden("report_forbidden", "anda tidak memiliki akses ke laporan project ini")
+	ErrInvalidReportRange   = utils.Validation("invalid_report_range", "from harus sebelum to dan rentang maksimal 366 hari")
+	ErrInvalidBurndownUnit  = utils.Validation("invalid_unit", "unit harus points, tasks atau hours")
+	ErrSprintRequired       = utils.Validation("sprint_required", "sprint wajib diisi karena project tidak memiliki sprint active")
+	ErrInvalidVelocityRange = utils.Validation("invalid_velocity_range", "from harus sebelum to, window_days antara 1 dan 366 dan jumlah window maksimal 104")
+	ErrInvalidCursor        = utils.Validation("invalid_cursor", "cursor tidak valid untuk parameter sort ini")
+	ErrInvalidMembership    = utils.Validation("invalid_membership", "membership harus owned, shared atau all")
+	ErrInvalidArchived      = utils.Validation("invalid_archived", "archived harus true, false atau all")
+	ErrInvalidSort          = utils.Validation("invalid_sort", "parameter sort tidak didukung")
+	ErrVersionMismatch      = utils.PreconditionFailed("version_mismatch", "data sudah diubah sejak terakhir dibaca (If-Match tidak cocok)")
+	ErrIfMatchRequired      = utils.PreconditionRequired("if_match_required", "header If-Match wajib dikirim, gunakan ETag dari response terakhir atau * untuk menimpa tanpa cek version")
+	ErrConcurrentUpdate     = utils.Conflict("concurrent_update", "data sedang diubah oleh user lain, silakan coba lagi")
+)
+
+// checkVersion memastikan version dari If-Match (0 berarti If-Match: *) sama dengan version saat ini
+func checkVersion(current, expected uint) error {
+	if expected != 0 && current != expected {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// listError menerjemahkan error pagination dari repository
+func listError(err error) error {
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return ErrInvalidCursor
+	}
+	if errors.Is(err, repository.ErrInvalidSort) {
+		return ErrInvalidSort
+	}
+	return err
+}
+
+// versionError menerjemahkan kegagalan compare-and-swap dari repository
+func versionError(err error, expected uint) error {
+	if errors.Is(err, repository.ErrStaleVersion) {
+		if expected != 0 {
+			return ErrVersionMismatch
+		}
+		return ErrConcurrentUpdate
+	}
+	return err
+}
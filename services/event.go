@@ -0,0 +1,21 @@
+package services
+
+import (
+	"PA/models"
+	"PA/repository"
+
+	"gorm.io/gorm"
+)
+
+// GetTaskHistoryService mengembalikan riwayat perubahan task, default terbaru dulu
+func GetTaskHistoryService(db *gorm.DB, taskID, userID uint, opts models.ListOptions) ([]models.TaskEvent, string, error) {
+	if _, err := getReadableTask(db, taskID, userID); err != nil {
+		return nil, "", err
+	}
+	if opts.Sort == "" {
+		opts.Sort = repository.DefaultSort
+		opts.Desc = true
+	}
+	events, next, err := repository.GetTaskEvents(db, taskID, opts)
+	return events, next, listError(err)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+func GetLabelsService(db *gorm.DB, project *models.Project, userID uint) ([]models.Label, error) {
+	if err := policy.Authorize(userID, policy.ViewProject, project); err != nil {
+		return nil, ErrProjectAccessDenied
+	}
+	return repository.GetProjectLabels(db, project.ID)
+}
+
+// CreateLabelService membuat label baru, boleh dilakukan role yang boleh membuat task
+func CreateLabelService(db *gorm.DB, project *models.Project, label *models.Label, userID uint) error {
+	if err := policy.Authorize(userID, policy.CreateTask, project); err != nil {
+		return ErrLabelManageDenied
+	}
+	if err := checkWritable(project); err != nil {
+		return err
+	}
+	label.Name = strings.TrimSpace(label.Name)
+	if label.Name == "" {
+		return ErrInvalidLabel
+	}
+	label.ID = 0
+	label.ProjectID = project.ID
+	if err := repository.CreateLabel(db, label); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrLabelExists
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteLabelService menghapus label dari project dan dari semua task, hanya owner/admin
+func DeleteLabelService(db *gorm.DB, project *models.Project, labelID, userID uint) error {
+	if err := policy.Authorize(userID, policy.DeleteTask, project); err != nil {
+		return ErrLabelManageDenied
+	}
+	if err := checkWritable(project); err != nil {
+		return err
+	}
+	label, err := getLabel(db, project.ID, labelID)
+	if err != nil {
+		return err
+	}
+	return repository.DeleteLabel(db, label.ID)
+}
+
+func getLabel(db *gorm.DB, projectID, labelID uint) (models.Label, error) {
+	label, err := repository.GetLabel(db, projectID, labelID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Label{}, ErrLabelNotFound
+		}
+		return models.Label{}, err
+	}
+	return label, nil
+}
+
+// AddTaskLabelService memasang label project ke task
+func AddTaskLabelService(db *gorm.DB, taskID, labelID, userID uint) ([]models.Label, error) {
+	task, err := getEditableTask(db, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	label, err := getLabel(db, task.ProjectID, labelID)
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.AddTaskLabel(db, task.ID, label.ID); err != nil {
+		return nil, err
+	}
+	return repository.GetTaskLabels(db, task.ID)
+}
+
+func RemoveTaskLabelService(db *gorm.DB, taskID, labelID, userID uint) ([]models.Label, error) {
+	task, err := getEditableTask(db, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := repository.RemoveTaskLabel(db, task.ID, labelID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrLabelNotFound
+		}
+		return nil, err
+	}
+	return repository.GetTaskLabels(db, task.ID)
+}
@@ -4,99 +4,256 @@ import (
 	"gorm.io/gorm"
 	
 	"errors"
+	"log"
 	"PA/models"
+	"PA/policy"
 	"PA/repository"
+	"time"
 )
 
-func GetAllProjectsService(db *gorm.DB, userID uint) ([]models.Project, error) {
-	return repository.GetAllProjects(db, userID)
+// GetAllProjectsService mengembalikan project milik user dan project tempat user menjadi collaborator,
+// lengkap dengan role user dan ringkasan jumlah task
+func GetAllProjectsService(db *gorm.DB, userID uint, filter models.ProjectFilter, opts models.ListOptions) ([]models.Project, string, error) {
+	if filter.Membership == "" {
+		filter.Membership = models.MembershipAll
+	}
+	if !models.IsValidMembership(filter.Membership) {
+		return nil, "", ErrInvalidMembership
+	}
+	if filter.Archived == "" {
+		filter.Archived = models.ArchivedExclude
+	}
+	if !models.IsValidArchivedFilter(filter.Archived) {
+		return nil, "", ErrInvalidArchived
+	}
+
+	projects, next, err := repository.GetAllProjects(db, userID, filter, opts)
+	if err != nil {
+		return nil, "", listError(err)
+	}
+
+	ids := make([]uint, len(projects))
+	for i := range projects {
+		ids[i] = projects[i].ID
+	}
+	summaries, err := repository.CountTasksByProject(db, ids)
+	if err != nil {
+		return nil, "", err
+	}
+
+	for i := range projects {
+		projects[i].Role = policy.RoleOf(&projects[i], userID)
+		summary := summaries[projects[i].ID]
+		projects[i].TaskSummary = &summary
+	}
+	return projects, next, nil
 }
 
-func GetProjectByIDService(db *gorm.DB, projectID uint, userID uint) (models.Project, error) {
+// GetProjectForUserService memuat project beserta collaborator dan memastikan user boleh melihatnya
+func GetProjectForUserService(db *gorm.DB, projectID, userID uint) (*models.Project, error) {
 	project, err := repository.GetProjectByID(db, projectID, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return models.Project{}, errors.New("project tidak ditemukan")
+			return nil, ErrProjectNotFound
 		}
-		return models.Project{}, err
+		return nil, err
+	}
+
+	if !policy.Can(userID, policy.ViewProject, &project) {
+		return nil, ErrProjectAccessDenied
+	}
+
+	return &project, nil
+}
+
+// checkWritable menolak perubahan pada project yang diarsipkan
+func checkWritable(project *models.Project) error {
+	if project.ArchivedAt != nil {
+		return ErrProjectArchived
+	}
+	return nil
+}
+
+// SetProjectArchivedService mengarsipkan atau mengaktifkan kembali project. Hanya owner, dan tidak
+// mengubah apa pun jika project sudah berada di state yang diminta.
+func SetProjectArchivedService(db *gorm.DB, project *models.Project, archived bool, expectedVersion uint, userID uint) error {
+	if err := policy.Authorize(userID, policy.ArchiveProject, project); err != nil {
+		return ErrArchiveDenied
+	}
+	if err := checkVersion(project.Version, expectedVersion); err != nil {
+		return err
+	}
+	if archived == (project.ArchivedAt != nil) {
+		return nil
+	}
+
+	var archivedAt *time.Time
+	if archived {
+		now := time.Now()
+		archivedAt = &now
 	}
+	return versionError(repository.SetProjectArchived(db, project, archivedAt, userID), expectedVersion)
+}
 
-	isCollaborator := false
-	for _, collaborator := range project.Collaborators {
-		if collaborator.UserID == userID {
-			isCollaborator = true
-			break
+// TransferProjectOwnershipService memindahkan kepemilikan project ke newOwnerID. Hanya owner saat ini atau
+// admin sistem, sehingga project milik user yang sudah tidak aktif tetap bisa dipindahkan walaupun admin
+// bukan anggota project. User lain yang bukan anggota mendapat ErrProjectNotFound agar keberadaan project
+// tidak bocor. previousOwnerRole kosong berarti owner lama tidak lagi menjadi anggota project.
+func TransferProjectOwnershipService(db *gorm.DB, projectID, newOwnerID uint, previousOwnerRole string, expectedVersion uint, userID uint) (models.Project, error) {
+	project, err := repository.GetProjectByID(db, projectID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Project{}, ErrProjectNotFound
+		}
+		return models.Project{}, err
+	}
+	if !policy.Can(userID, policy.TransferOwnership, &project) {
+		user, err := repository.GetUserByID(db, userID)
+		if err != nil {
+			return models.Project{}, err
+		}
+		if !user.IsAdmin {
+			if !policy.IsMember(&project, userID) {
+				return models.Project{}, ErrProjectNotFound
+			}
+			return models.Project{}, ErrTransferDenied
 		}
 	}
 
-	if project.OwnerID != userID && !isCollaborator {
-		return models.Project{}, errors.New("anda tidak memiliki akses ke project ini")
+	if previousOwnerRole != "" && !models.IsValidCollaboratorRole(previousOwnerRole) {
+		return models.Project{}, ErrInvalidRole
+	}
+	if newOwnerID == project.OwnerID {
+		return models.Project{}, ErrAlreadyOwner
+	}
+	if _, err := repository.GetUserByID(db, newOwnerID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Project{}, ErrUserNotFound
+		}
+		return models.Project{}, err
+	}
+	if err := checkVersion(project.Version, expectedVersion); err != nil {
+		return models.Project{}, err
 	}
 
-	return project, nil
+	if err := repository.TransferProjectOwnership(db, &project, newOwnerID, previousOwnerRole, userID); err != nil {
+		return models.Project{}, versionError(err, expectedVersion)
+	}
+	return repository.GetProjectByID(db, project.ID, userID)
 }
 
 func CreateProjectService(db *gorm.DB, project *models.Project) error {
 	return repository.CreateProject(db, project)
 }
 
-func UpdateProjectService(db *gorm.DB, project *models.Project, userID uint) error {
-    isOwner, err := repository.IsOwner(db, project.ID, userID)
-    if err != nil {
+// UpdateProjectService menyimpan perubahan project. expectedVersion berasal dari If-Match, 0 jika tidak dikirim.
+func UpdateProjectService(db *gorm.DB, project *models.Project, expectedVersion uint, userID uint) error {
+    if err := policy.Authorize(userID, policy.UpdateProject, project); err != nil {
+        return ErrProjectUpdateDenied
+    }
+    if err := checkWritable(project); err != nil {
         return err
     }
-    if !isOwner {
-        return errors.New("unauthorized: hanya owner yang bisa mengupdate project")
+    if err := checkVersion(project.Version, expectedVersion); err != nil {
+        return err
     }
     
-    return repository.UpdateProject(db, project, userID)
+    return versionError(repository.UpdateProject(db, project, userID), expectedVersion)
 }
 
-func DeleteProjectService(db *gorm.DB, projectID uint, userID uint) error {
-    var project models.Project
-    if err := db.First(&project, projectID).Error; err != nil {
-        if errors.Is(err, gorm.ErrRecordNotFound) {
-            return errors.New("project tidak ditemukan")
-        }
+// DeleteProjectService menghapus project. Percobaan hapus yang ditolak atau gagal karena version
+// sudah berubah dicatat ke riwayat project.
+func DeleteProjectService(db *gorm.DB, project *models.Project, expectedVersion uint, userID uint) error {
+    if err := policy.Authorize(userID, policy.DeleteProject, project); err != nil {
+        recordDeleteAttempt(db, project, models.DeleteAttemptDenied, userID)
+        return ErrProjectDeleteDenied
+    }
+    if err := checkVersion(project.Version, expectedVersion); err != nil {
+        recordDeleteAttempt(db, project, models.DeleteAttemptStale, userID)
         return err
     }
-    if project.OwnerID != userID {
-        return errors.New("unauthorized: hanya owner yang bisa menghapus project")
+
+    err := repository.DeleteProject(db, project.ID, project.OwnerID, project.Version, userID)
+    if errors.Is(err, repository.ErrStaleVersion) {
+        recordDeleteAttempt(db, project, models.DeleteAttemptStale, userID)
     }
-    return repository.DeleteProject(db, projectID, userID)
+    return versionError(err, expectedVersion)
 }
 
-func AddCollaboratorService(db *gorm.DB, projectID, userID, ownerID uint) error {
-    var project models.Project
-    if err := db.First(&project, projectID).Error; err != nil {
+// recordDeleteAttempt mencatat percobaan hapus project yang gagal. Kegagalan mencatat hanya di-log
+// agar error asli tetap dikembalikan ke user.
+func recordDeleteAttempt(db *gorm.DB, project *models.Project, reason string, userID uint) {
+    event := models.ProjectEvent{ProjectID: project.ID, Action: models.ProjectEventDeleteAttempted, Note: reason}
+    if err := repository.RecordProjectEvent(db, event, userID); err != nil {
+        log.Printf("gagal mencatat percobaan hapus project %d: %v", project.ID, err)
+    }
+}
+
+func AddCollaboratorService(db *gorm.DB, project *models.Project, userID uint, role string, currentUserID uint) error {
+    if err := policy.Authorize(currentUserID, policy.ManageCollaborators, project); err != nil {
+        return ErrCollaboratorDenied
+    }
+    if err := checkWritable(project); err != nil {
+        return err
+    }
+
+    if role == "" {
+        role = models.RoleMember
+    }
+    if !models.IsValidCollaboratorRole(role) {
+        return ErrInvalidRole
+    }
+    if policy.IsMember(project, userID) {
+        return ErrAlreadyMember
+    }
+    if _, err := repository.GetUserByID(db, userID); err != nil {
         if errors.Is(err, gorm.ErrRecordNotFound) {
-            return errors.New("project tidak ditemukan")
+            return ErrUserNotFound
         }
         return err
     }
-    if project.OwnerID != ownerID {
-        return errors.New("tidak diperbolehkan karena anda bukan owner")
-    }
-    return repository.InviteCollaborator(db, projectID, userID)
+
+    return repository.InviteCollaborator(db, project.ID, userID, role, currentUserID)
 }
 
-func RemoveCollaboratorService(db *gorm.DB, projectID, userID, ownerID uint) error {
-    isOwner, err := repository.IsOwner(db, projectID, ownerID)
-    if err != nil {
+func UpdateCollaboratorRoleService(db *gorm.DB, project *models.Project, userID uint, role string, currentUserID uint) error {
+    if err := policy.Authorize(currentUserID, policy.ManageCollaborators, project); err != nil {
+        return ErrCollaboratorDenied
+    }
+    if err := checkWritable(project); err != nil {
         return err
     }
+    if !models.IsValidCollaboratorRole(role) {
+        return ErrInvalidRole
+    }
+    if userID == project.OwnerID {
+        return ErrOwnerRoleImmutable
+    }
+
+    err := repository.UpdateCollaboratorRole(db, project.ID, userID, role, currentUserID)
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return ErrCollaboratorNotFound
+    }
+    return err
+}
 
-    if !isOwner {
-        if userID != ownerID {
-            return errors.New("tidak diperbolehkan menghapus collaborator lain kecuali diri sendiri")
+func RemoveCollaboratorService(db *gorm.DB, project *models.Project, userID, currentUserID uint) error {
+    // Collaborator biasa hanya boleh keluar dari project (menghapus dirinya sendiri). Keluar dari project
+    // tetap boleh walaupun project diarsipkan.
+    if userID != currentUserID {
+        if err := policy.Authorize(currentUserID, policy.ManageCollaborators, project); err != nil {
+            return ErrCollaboratorDenied
+        }
+        if err := checkWritable(project); err != nil {
+            return err
         }
     }
 
-    err = repository.RemoveCollaborator(db, projectID, userID)
+    err := repository.RemoveCollaborator(db, project.ID, userID, currentUserID)
     
     if errors.Is(err, gorm.ErrRecordNotFound) {
-        return errors.New("collaborator tidak ditemukan di project ini")
+        return ErrCollaboratorNotFound
     }
     
     return err
-}
\ No newline at end of file
+}
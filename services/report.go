@@ -0,0 +1,240 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaxReportDays membatasi panjang series harian
+const MaxReportDays = 366
+
+// statusTimeline menelusuri riwayat status satu task secara berurutan. current nil berarti task belum dibuat.
+type statusTimeline struct {
+	changes []models.TaskStatusChange
+	next    int
+	current *models.TaskStatusChange
+}
+
+// advance menerapkan semua perubahan sebelum until
+func (t *statusTimeline) advance(until time.Time) *models.TaskStatusChange {
+	for t.next < len(t.changes) && t.changes[t.next].ChangedAt.Before(until) {
+		t.current = &t.changes[t.next]
+		t.next++
+	}
+	return t.current
+}
+
+// buildTimelines mengelompokkan riwayat (sudah terurut per task) menjadi timeline per task
+func buildTimelines(changes []models.TaskStatusChange) map[uint]*statusTimeline {
+	timelines := make(map[uint]*statusTimeline)
+	for i := 0; i < len(changes); {
+		j := i
+		for j < len(changes) && changes[j].TaskID == changes[i].TaskID {
+			j++
+		}
+		timelines[changes[i].TaskID] = &statusTimeline{changes: changes[i:j]}
+		i = j
+	}
+	return timelines
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// burndownItem adalah task sprint beserta bobotnya sesuai unit
+type burndownItem struct {
+	taskID uint
+	weight float64
+}
+
+func burndownWeight(unit string, storyPoints *int, estimatedHours *float64) float64 {
+	switch unit {
+	case models.BurndownTasks:
+		return 1
+	case models.BurndownHours:
+		if estimatedHours != nil {
+			return *estimatedHours
+		}
+	default:
+		if storyPoints != nil {
+			return float64(*storyPoints)
+		}
+	}
+	return 0
+}
+
+// GetBurndownService menghitung burndown sprint (sprintID 0 berarti sprint active) dari riwayat status.
+// Task sprint closed diambil dari snapshot, selain itu dari task yang saat ini ada di sprint.
+// Bobot memakai estimasi terakhir karena riwayat estimasi tidak disimpan.
+func GetBurndownService(db *gorm.DB, project *models.Project, userID uint, sprintID uint, unit string) (models.Burndown, error) {
+	if err := policy.Authorize(userID, policy.ViewAllTasks, project); err != nil {
+		return models.Burndown{}, ErrReportDenied
+	}
+	if unit == "" {
+		unit = models.BurndownPoints
+	}
+	if unit != models.BurndownPoints && unit != models.BurndownTasks && unit != models.BurndownHours {
+		return models.Burndown{}, ErrInvalidBurndownUnit
+	}
+
+	sprint, err := reportSprint(db, project, sprintID)
+	if err != nil {
+		return models.Burndown{}, err
+	}
+
+	var items []burndownItem
+	if sprint.State == models.SprintClosed {
+		snapshots, err := repository.GetSprintSnapshot(db, sprint.ID)
+		if err != nil {
+			return models.Burndown{}, err
+		}
+		for _, snapshot := range snapshots {
+			items = append(items, burndownItem{snapshot.TaskID, burndownWeight(unit, snapshot.StoryPoints, snapshot.EstimatedHours)})
+		}
+	} else {
+		tasks, err := repository.GetSprintTasks(db, sprint.ID)
+		if err != nil {
+			return models.Burndown{}, err
+		}
+		for _, task := range tasks {
+			items = append(items, burndownItem{task.ID, burndownWeight(unit, task.StoryPoints, task.EstimatedHours)})
+		}
+	}
+
+	start := truncateDay(sprint.StartDate)
+	end := truncateDay(sprint.EndDate)
+	if end.Sub(start) > MaxReportDays*day {
+		end = start.Add(MaxReportDays * day)
+	}
+
+	taskIDs := make([]uint, len(items))
+	for i, item := range items {
+		taskIDs[i] = item.taskID
+	}
+	changes, err := repository.GetStatusChanges(db, project.ID, taskIDs, end.Add(day))
+	if err != nil {
+		return models.Burndown{}, err
+	}
+	timelines := buildTimelines(changes)
+
+	burndown := models.Burndown{SprintID: sprint.ID, Unit: unit, Days: []models.BurndownDay{}}
+	days := int(end.Sub(start)/day) + 1
+	now := time.Now()
+	var initial float64
+	for i := 0; i < days; i++ {
+		date := start.Add(time.Duration(i) * day)
+		dayEnd := date.Add(day)
+
+		var total, remaining float64
+		for _, item := range items {
+			timeline, ok := timelines[item.taskID]
+			if !ok {
+				continue
+			}
+			current := timeline.advance(dayEnd)
+			if current == nil {
+				continue
+			}
+			total += item.weight
+			if current.ToCategory != models.StatusCategoryDone {
+				remaining += item.weight
+			}
+		}
+		if i == 0 {
+			initial = total
+		}
+
+		point := models.BurndownDay{Date: date.Format("2006-01-02")}
+		if date.Before(now) {
+			total, remaining := round2(total), round2(remaining)
+			point.Total = &total
+			point.Remaining = &remaining
+		}
+		if days > 1 {
+			point.Ideal = round2(initial * float64(days-1-i) / float64(days-1))
+		}
+		burndown.Days = append(burndown.Days, point)
+	}
+	return burndown, nil
+}
+
+// reportSprint memuat sprint yang diminta, atau sprint active jika sprintID 0
+func reportSprint(db *gorm.DB, project *models.Project, sprintID uint) (models.Sprint, error) {
+	if sprintID != 0 {
+		return getSprint(db, project, sprintID)
+	}
+	sprints, err := repository.GetProjectSprints(db, project.ID)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	for _, sprint := range sprints {
+		if sprint.State == models.SprintActive {
+			return sprint, nil
+		}
+	}
+	return models.Sprint{}, ErrSprintRequired
+}
+
+// GetCumulativeFlowService menghitung jumlah task project per status pada akhir setiap hari dari from sampai to
+// (inklusif) berdasarkan riwayat status. Urutan status mengikuti workflow, status lama yang sudah tidak ada
+// di workflow ditambahkan di akhir.
+func GetCumulativeFlowService(db *gorm.DB, project *models.Project, userID uint, from, to time.Time) (models.CumulativeFlow, error) {
+	if err := policy.Authorize(userID, policy.ViewAllTasks, project); err != nil {
+		return models.CumulativeFlow{}, ErrReportDenied
+	}
+	from, to = truncateDay(from), truncateDay(to)
+	if to.Before(from) || to.Sub(from) >= MaxReportDays*day {
+		return models.CumulativeFlow{}, ErrInvalidReportRange
+	}
+
+	workflow, err := repository.GetWorkflow(db, project.ID)
+	if err != nil {
+		return models.CumulativeFlow{}, err
+	}
+	changes, err := repository.GetStatusChanges(db, project.ID, nil, to.Add(day))
+	if err != nil {
+		return models.CumulativeFlow{}, err
+	}
+	timelines := buildTimelines(changes)
+
+	flow := models.CumulativeFlow{Statuses: []models.CumulativeFlowStatus{}, Days: []models.CumulativeFlowDay{}}
+	known := make(map[string]bool)
+	for _, status := range workflow.Statuses {
+		flow.Statuses = append(flow.Statuses, models.CumulativeFlowStatus{Name: status.Name, Category: status.Category})
+		known[status.Name] = true
+	}
+
+	for date := from; !date.After(to); date = date.Add(day) {
+		counts := make(map[string]int64, len(flow.Statuses))
+		for _, status := range flow.Statuses {
+			counts[status.Name] = 0
+		}
+		for _, timeline := range timelines {
+			current := timeline.advance(date.Add(day))
+			if current == nil {
+				continue
+			}
+			if !known[current.ToStatus] {
+				known[current.ToStatus] = true
+				flow.Statuses = append(flow.Statuses, models.CumulativeFlowStatus{Name: current.ToStatus, Category: current.ToCategory})
+			}
+			counts[current.ToStatus]++
+		}
+		flow.Days = append(flow.Days, models.CumulativeFlowDay{Date: date.Format("2006-01-02"), Counts: counts})
+	}
+
+	// status lama yang muncul belakangan tetap bernilai 0 di hari-hari sebelumnya
+	for _, point := range flow.Days {
+		for _, status := range flow.Statuses {
+			if _, ok := point.Counts[status.Name]; !ok {
+				point.Counts[status.Name] = 0
+			}
+		}
+	}
+	return flow, nil
+}
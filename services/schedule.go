@@ -0,0 +1,197 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const day = 24 * time.Hour
+
+// GetProjectScheduleService menghitung schedule project dengan metode critical path. Dependency antar task
+// di project yang sama dipakai sebagai link finish-to-start, dependency lintas project diabaikan.
+func GetProjectScheduleService(db *gorm.DB, project *models.Project, userID uint, start time.Time) (models.Schedule, error) {
+	if err := policy.Authorize(userID, policy.ViewAllTasks, project); err != nil {
+		return models.Schedule{}, ErrReportDenied
+	}
+
+	tasks, err := repository.GetProjectTasks(db, project.ID)
+	if err != nil {
+		return models.Schedule{}, err
+	}
+	dependencies, err := repository.GetProjectDependencies(db, project.ID)
+	if err != nil {
+		return models.Schedule{}, err
+	}
+
+	schedule, err := computeSchedule(tasks, dependencies, start)
+	schedule.ProjectID = project.ID
+	return schedule, err
+}
+
+type scheduleNode struct {
+	task         models.Task
+	duration     int
+	predecessors []int
+	successors   []int
+	es, ef       int
+	ls, lf       int
+}
+
+// computeSchedule menjalankan forward pass (earliest start/finish) dan backward pass (latest start/finish)
+// dalam satuan hari sejak start. Task tanpa estimasi dihitung models.DefaultDurationDays.
+func computeSchedule(tasks []models.Task, dependencies []models.TaskDependency, start time.Time) (models.Schedule, error) {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	nodes := make([]scheduleNode, len(tasks))
+	index := make(map[uint]int, len(tasks))
+	for i, task := range tasks {
+		nodes[i] = scheduleNode{task: task, duration: task.DurationDays}
+		if nodes[i].duration <= 0 {
+			nodes[i].duration = models.DefaultDurationDays
+		}
+		index[task.ID] = i
+	}
+	for _, dependency := range dependencies {
+		from, okFrom := index[dependency.BlockerID]
+		to, okTo := index[dependency.BlockedID]
+		if !okFrom || !okTo {
+			continue
+		}
+		nodes[from].successors = append(nodes[from].successors, to)
+		nodes[to].predecessors = append(nodes[to].predecessors, from)
+	}
+
+	order, ok := topologicalOrder(nodes)
+	if !ok {
+		return models.Schedule{}, ErrDependencyCycle
+	}
+
+	finish := 0
+	for _, i := range order {
+		node := &nodes[i]
+		for _, p := range node.predecessors {
+			if nodes[p].ef > node.es {
+				node.es = nodes[p].ef
+			}
+		}
+		node.ef = node.es + node.duration
+		if node.ef > finish {
+			finish = node.ef
+		}
+	}
+
+	for k := len(order) - 1; k >= 0; k-- {
+		node := &nodes[order[k]]
+		node.lf = finish
+		for _, s := range node.successors {
+			if nodes[s].ls < node.lf {
+				node.lf = nodes[s].ls
+			}
+		}
+		node.ls = node.lf - node.duration
+	}
+
+	schedule := models.Schedule{
+		Start:               start,
+		Finish:              start.Add(time.Duration(finish) * day),
+		DurationDays:        finish,
+		DefaultDurationDays: models.DefaultDurationDays,
+		CriticalPath:        criticalPath(nodes, finish),
+		Tasks:               make([]models.ScheduledTask, len(nodes)),
+	}
+	for i, node := range nodes {
+		item := models.ScheduledTask{
+			ID:              node.task.ID,
+			Title:           node.task.Title,
+			Status:          node.task.Status,
+			ParentID:        node.task.ParentID,
+			DurationDays:    node.duration,
+			EstimateMissing: node.task.DurationDays <= 0,
+			Predecessors:    make([]uint, len(node.predecessors)),
+			EarliestStart:   start.Add(time.Duration(node.es) * day),
+			EarliestFinish:  start.Add(time.Duration(node.ef) * day),
+			LatestStart:     start.Add(time.Duration(node.ls) * day),
+			LatestFinish:    start.Add(time.Duration(node.lf) * day),
+			SlackDays:       node.ls - node.es,
+			Critical:        node.ls == node.es,
+		}
+		for j, p := range node.predecessors {
+			item.Predecessors[j] = nodes[p].task.ID
+		}
+		if !node.task.Deadline.IsZero() {
+			deadline := node.task.Deadline
+			// deadline berlaku sampai akhir hari deadline, sehingga dibandingkan dengan finish eksklusif hari berikutnya
+			deadlineDay := int(time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, time.UTC).Sub(start)/day) + 1
+			slack := deadlineDay - node.ef
+			item.Deadline = &deadline
+			item.DeadlineSlackDays = &slack
+			item.Late = slack < 0
+		}
+		if item.EstimateMissing {
+			schedule.MissingEstimates++
+		}
+		schedule.Tasks[i] = item
+	}
+
+	sort.SliceStable(schedule.Tasks, func(a, b int) bool {
+		return schedule.Tasks[a].EarliestStart.Before(schedule.Tasks[b].EarliestStart)
+	})
+	return schedule, nil
+}
+
+// topologicalOrder mengurutkan node dengan algoritma Kahn, false jika terdapat siklus
+func topologicalOrder(nodes []scheduleNode) ([]int, bool) {
+	inDegree := make([]int, len(nodes))
+	queue := []int{}
+	for i, node := range nodes {
+		inDegree[i] = len(node.predecessors)
+		if inDegree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	order := make([]int, 0, len(nodes))
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		order = append(order, i)
+		for _, s := range nodes[i].successors {
+			inDegree[s]--
+			if inDegree[s] == 0 {
+				queue = append(queue, s)
+			}
+		}
+	}
+	return order, len(order) == len(nodes)
+}
+
+// criticalPath menelusuri satu rantai task tanpa slack, mulai dari task yang selesai paling akhir
+// mundur melalui predecessor yang langsung mendahuluinya
+func criticalPath(nodes []scheduleNode, finish int) []uint {
+	current := -1
+	for i, node := range nodes {
+		if node.ef == finish && node.ls == node.es {
+			current = i
+			break
+		}
+	}
+
+	path := []uint{}
+	for current >= 0 {
+		path = append([]uint{nodes[current].task.ID}, path...)
+		next := -1
+		for _, p := range nodes[current].predecessors {
+			if nodes[p].ef == nodes[current].es && nodes[p].ls == nodes[p].es {
+				next = p
+				break
+			}
+		}
+		current = next
+	}
+	return path
+}
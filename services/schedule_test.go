@@ -0,0 +1,160 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"PA/models"
+)
+
+var scheduleStart = time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+func link(blocker, blocked uint) models.TaskDependency {
+	return models.TaskDependency{BlockerID: blocker, BlockedID: blocked}
+}
+
+func scheduledByID(t *testing.T, schedule models.Schedule) map[uint]models.ScheduledTask {
+	t.Helper()
+	byID := make(map[uint]models.ScheduledTask, len(schedule.Tasks))
+	for _, task := range schedule.Tasks {
+		byID[task.ID] = task
+	}
+	return byID
+}
+
+// expectTask membandingkan earliest start (hari sejak start), slack dan status critical
+func expectTask(t *testing.T, task models.ScheduledTask, es, slack int, critical bool) {
+	t.Helper()
+	if got := int(task.EarliestStart.Sub(scheduleStart) / day); got != es {
+		t.Errorf("task %d earliest start = hari %d, want %d", task.ID, got, es)
+	}
+	if task.SlackDays != slack {
+		t.Errorf("task %d slack = %d, want %d", task.ID, task.SlackDays, slack)
+	}
+	if task.Critical != critical {
+		t.Errorf("task %d critical = %v, want %v", task.ID, task.Critical, critical)
+	}
+}
+
+func TestComputeScheduleChain(t *testing.T) {
+	tasks := []models.Task{
+		{ID: 1, DurationDays: 2},
+		{ID: 2, DurationDays: 3},
+		{ID: 3, DurationDays: 1},
+	}
+	schedule, err := computeSchedule(tasks, []models.TaskDependency{link(1, 2), link(2, 3)}, scheduleStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schedule.DurationDays != 6 {
+		t.Errorf("duration = %d, want 6", schedule.DurationDays)
+	}
+	if want := scheduleStart.Add(6 * day); !schedule.Finish.Equal(want) {
+		t.Errorf("finish = %v, want %v", schedule.Finish, want)
+	}
+	if want := []uint{1, 2, 3}; !reflect.DeepEqual(schedule.CriticalPath, want) {
+		t.Errorf("critical path = %v, want %v", schedule.CriticalPath, want)
+	}
+	byID := scheduledByID(t, schedule)
+	expectTask(t, byID[1], 0, 0, true)
+	expectTask(t, byID[2], 2, 0, true)
+	expectTask(t, byID[3], 5, 0, true)
+}
+
+func TestComputeScheduleDiamond(t *testing.T) {
+	// 1 -> 2 -> 4 dan 1 -> 3 -> 4, cabang lewat 3 lebih panjang sehingga 2 memiliki slack
+	tasks := []models.Task{
+		{ID: 1, DurationDays: 1},
+		{ID: 2, DurationDays: 2},
+		{ID: 3, DurationDays: 4},
+		{ID: 4, DurationDays: 1},
+	}
+	dependencies := []models.TaskDependency{link(1, 2), link(1, 3), link(2, 4), link(3, 4)}
+	schedule, err := computeSchedule(tasks, dependencies, scheduleStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schedule.DurationDays != 6 {
+		t.Errorf("duration = %d, want 6", schedule.DurationDays)
+	}
+	if want := []uint{1, 3, 4}; !reflect.DeepEqual(schedule.CriticalPath, want) {
+		t.Errorf("critical path = %v, want %v", schedule.CriticalPath, want)
+	}
+	byID := scheduledByID(t, schedule)
+	expectTask(t, byID[1], 0, 0, true)
+	expectTask(t, byID[2], 1, 2, false)
+	expectTask(t, byID[3], 1, 0, true)
+	expectTask(t, byID[4], 5, 0, true)
+	if got := byID[4].Predecessors; len(got) != 2 {
+		t.Errorf("predecessors task 4 = %v, want 2 task", got)
+	}
+}
+
+func TestComputeScheduleMissingEstimate(t *testing.T) {
+	tasks := []models.Task{
+		{ID: 1, DurationDays: 3},
+		{ID: 2},
+	}
+	schedule, err := computeSchedule(tasks, []models.TaskDependency{link(1, 2)}, scheduleStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schedule.DefaultDurationDays != models.DefaultDurationDays {
+		t.Errorf("default duration = %d, want %d", schedule.DefaultDurationDays, models.DefaultDurationDays)
+	}
+	if schedule.MissingEstimates != 1 {
+		t.Errorf("missing estimates = %d, want 1", schedule.MissingEstimates)
+	}
+	if want := 3 + models.DefaultDurationDays; schedule.DurationDays != want {
+		t.Errorf("duration = %d, want %d", schedule.DurationDays, want)
+	}
+	byID := scheduledByID(t, schedule)
+	if byID[1].EstimateMissing {
+		t.Error("task 1 memiliki estimasi tetapi ditandai estimate_missing")
+	}
+	if !byID[2].EstimateMissing || byID[2].DurationDays != models.DefaultDurationDays {
+		t.Errorf("task 2 = %+v, want estimate_missing dengan durasi default", byID[2])
+	}
+}
+
+func TestComputeScheduleIgnoresOutsideDependencies(t *testing.T) {
+	tasks := []models.Task{{ID: 1, DurationDays: 2}}
+	schedule, err := computeSchedule(tasks, []models.TaskDependency{link(99, 1)}, scheduleStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectTask(t, scheduledByID(t, schedule)[1], 0, 0, true)
+}
+
+func TestComputeScheduleCycle(t *testing.T) {
+	tasks := []models.Task{{ID: 1, DurationDays: 1}, {ID: 2, DurationDays: 1}}
+	_, err := computeSchedule(tasks, []models.TaskDependency{link(1, 2), link(2, 1)}, scheduleStart)
+	if !errors.Is(err, ErrDependencyCycle) {
+		t.Errorf("err = %v, want ErrDependencyCycle", err)
+	}
+}
+
+func TestComputeScheduleDeadline(t *testing.T) {
+	// deadline hari ke-2 (inklusif) untuk task 2 hari masih tepat waktu, task 3 hari terlambat 1 hari
+	deadline := scheduleStart.Add(day)
+	tasks := []models.Task{
+		{ID: 1, DurationDays: 2, Deadline: deadline},
+		{ID: 2, DurationDays: 3, Deadline: deadline},
+	}
+	schedule, err := computeSchedule(tasks, nil, scheduleStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := scheduledByID(t, schedule)
+	if task := byID[1]; task.Late || *task.DeadlineSlackDays != 0 {
+		t.Errorf("task 1 late = %v, slack = %d, want tepat waktu dengan slack 0", task.Late, *task.DeadlineSlackDays)
+	}
+	if task := byID[2]; !task.Late || *task.DeadlineSlackDays != -1 {
+		t.Errorf("task 2 late = %v, slack = %d, want terlambat 1 hari", task.Late, *task.DeadlineSlackDays)
+	}
+}
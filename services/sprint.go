@@ -0,0 +1,335 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func getSprint(db *gorm.DB, project *models.Project, sprintID uint) (models.Sprint, error) {
+	sprint, err := repository.GetSprint(db, project.ID, sprintID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Sprint{}, ErrSprintNotFound
+		}
+		return models.Sprint{}, err
+	}
+	return sprint, nil
+}
+
+// getManagedSprint memuat sprint yang akan diubah oleh owner/admin
+func getManagedSprint(db *gorm.DB, project *models.Project, sprintID, userID uint) (models.Sprint, error) {
+	if err := policy.Authorize(userID, policy.ManageSprints, project); err != nil {
+		return models.Sprint{}, ErrSprintManageDenied
+	}
+	if err := checkWritable(project); err != nil {
+		return models.Sprint{}, err
+	}
+	return getSprint(db, project, sprintID)
+}
+
+func validateSprint(sprint *models.Sprint) error {
+	sprint.Name = strings.TrimSpace(sprint.Name)
+	if sprint.Name == "" || sprint.StartDate.IsZero() || !sprint.EndDate.After(sprint.StartDate) {
+		return ErrInvalidSprint
+	}
+	return nil
+}
+
+func GetSprintsService(db *gorm.DB, project *models.Project, userID uint) ([]models.Sprint, error) {
+	if err := policy.Authorize(userID, policy.ViewProject, project); err != nil {
+		return nil, ErrProjectAccessDenied
+	}
+	return repository.GetProjectSprints(db, project.ID)
+}
+
+// GetSprintService mengembalikan sprint beserta ringkasannya. Snapshot sprint closed hanya untuk role
+// yang boleh melihat semua task, karena berisi judul task.
+func GetSprintService(db *gorm.DB, project *models.Project, sprintID, userID uint) (models.Sprint, error) {
+	if err := policy.Authorize(userID, policy.ViewProject, project); err != nil {
+		return models.Sprint{}, ErrProjectAccessDenied
+	}
+	sprint, err := getSprint(db, project, sprintID)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	if err := loadSprintSummary(db, &sprint, policy.Can(userID, policy.ViewAllTasks, project)); err != nil {
+		return models.Sprint{}, err
+	}
+	return sprint, nil
+}
+
+// loadSprintSummary menghitung ringkasan dari task saat ini, atau dari snapshot untuk sprint closed
+func loadSprintSummary(db *gorm.DB, sprint *models.Sprint, withSnapshot bool) error {
+	summary := models.SprintSummary{}
+	if sprint.State == models.SprintClosed {
+		snapshots, err := repository.GetSprintSnapshot(db, sprint.ID)
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range snapshots {
+			addToSummary(&summary, snapshot.StoryPoints, snapshot.EstimatedHours, snapshot.Completed)
+		}
+		if withSnapshot {
+			sprint.Snapshot = snapshots
+		}
+		sprint.Summary = &summary
+		return nil
+	}
+
+	tasks, err := repository.GetSprintTasks(db, sprint.ID)
+	if err != nil {
+		return err
+	}
+	workflow, err := repository.GetWorkflow(db, sprint.ProjectID)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		addToSummary(&summary, task.StoryPoints, task.EstimatedHours, workflow.CategoryOf(task.Status) == models.StatusCategoryDone)
+	}
+	sprint.Summary = &summary
+	return nil
+}
+
+func addToSummary(summary *models.SprintSummary, storyPoints *int, estimatedHours *float64, completed bool) {
+	summary.TotalTasks++
+	if storyPoints != nil {
+		summary.TotalPoints += int64(*storyPoints)
+	}
+	if !completed {
+		return
+	}
+	summary.CompletedTasks++
+	if storyPoints != nil {
+		summary.CompletedPoints += int64(*storyPoints)
+	}
+	if estimatedHours != nil {
+		summary.CompletedHours += *estimatedHours
+	}
+}
+
+func CreateSprintService(db *gorm.DB, project *models.Project, sprint *models.Sprint, userID uint) error {
+	if err := policy.Authorize(userID, policy.ManageSprints, project); err != nil {
+		return ErrSprintManageDenied
+	}
+	if err := checkWritable(project); err != nil {
+		return err
+	}
+	if err := validateSprint(sprint); err != nil {
+		return err
+	}
+	sprint.ID = 0
+	sprint.ProjectID = project.ID
+	sprint.State = models.SprintPlanned
+	return repository.CreateSprint(db, sprint)
+}
+
+// UpdateSprintService mengubah nama, goal dan tanggal sprint yang belum ditutup
+func UpdateSprintService(db *gorm.DB, project *models.Project, sprintID uint, changes models.Sprint, userID uint) (models.Sprint, error) {
+	sprint, err := getManagedSprint(db, project, sprintID, userID)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	if sprint.State == models.SprintClosed {
+		return models.Sprint{}, ErrSprintClosed
+	}
+
+	sprint.Name = changes.Name
+	sprint.Goal = changes.Goal
+	sprint.StartDate = changes.StartDate
+	sprint.EndDate = changes.EndDate
+	if err := validateSprint(&sprint); err != nil {
+		return models.Sprint{}, err
+	}
+	if err := repository.UpdateSprint(db, &sprint); err != nil {
+		return models.Sprint{}, err
+	}
+	return sprint, nil
+}
+
+// DeleteSprintService menghapus sprint planned, task di dalamnya kembali ke backlog
+func DeleteSprintService(db *gorm.DB, project *models.Project, sprintID, userID uint) error {
+	sprint, err := getManagedSprint(db, project, sprintID, userID)
+	if err != nil {
+		return err
+	}
+	if sprint.State != models.SprintPlanned {
+		return ErrSprintNotPlanned
+	}
+	return repository.DeleteSprint(db, sprint.ID, userID)
+}
+
+// StartSprintService mengaktifkan sprint planned. Project hanya boleh memiliki satu sprint active.
+func StartSprintService(db *gorm.DB, project *models.Project, sprintID, userID uint) (models.Sprint, error) {
+	sprint, err := getManagedSprint(db, project, sprintID, userID)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	if sprint.State != models.SprintPlanned {
+		return models.Sprint{}, ErrSprintNotPlanned
+	}
+
+	sprint.State = models.SprintActive
+	if err := repository.SetSprintState(db, &sprint, models.SprintPlanned); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrDuplicatedKey):
+			return models.Sprint{}, ErrSprintAlreadyActive
+		case errors.Is(err, repository.ErrSprintStateChanged):
+			return models.Sprint{}, ErrSprintNotPlanned
+		}
+		return models.Sprint{}, err
+	}
+	return sprint, nil
+}
+
+// CloseSprintService menutup sprint active. Task yang belum berkategori done dipindah ke nextSprintID,
+// atau ke backlog jika nil, dan keadaan seluruh task sprint disimpan sebagai snapshot.
+func CloseSprintService(db *gorm.DB, project *models.Project, sprintID uint, nextSprintID *uint, userID uint) (models.Sprint, error) {
+	sprint, err := getManagedSprint(db, project, sprintID, userID)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	if sprint.State != models.SprintActive {
+		return models.Sprint{}, ErrSprintNotActive
+	}
+	if nextSprintID != nil {
+		next, err := repository.GetSprint(db, project.ID, *nextSprintID)
+		if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && (next.ID == sprint.ID || next.State == models.SprintClosed)) {
+			return models.Sprint{}, ErrInvalidNextSprint
+		}
+		if err != nil {
+			return models.Sprint{}, err
+		}
+	}
+
+	tasks, err := repository.GetSprintTasks(db, sprint.ID)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	workflow, err := repository.GetWorkflow(db, project.ID)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+
+	snapshots := make([]models.SprintTaskSnapshot, len(tasks))
+	unfinished := []uint{}
+	for i, task := range tasks {
+		completed := workflow.CategoryOf(task.Status) == models.StatusCategoryDone
+		snapshots[i] = models.SprintTaskSnapshot{
+			SprintID:       sprint.ID,
+			TaskID:         task.ID,
+			Title:          task.Title,
+			Status:         task.Status,
+			StoryPoints:    task.StoryPoints,
+			EstimatedHours: task.EstimatedHours,
+			Completed:      completed,
+			CompletedAt:    task.CompletedAt,
+		}
+		if !completed {
+			snapshots[i].MovedToSprintID = nextSprintID
+			unfinished = append(unfinished, task.ID)
+		}
+	}
+
+	now := time.Now()
+	sprint.State = models.SprintClosed
+	sprint.ClosedAt = &now
+	sprint.ClosedByID = &userID
+	if err := repository.CloseSprint(db, &sprint, snapshots, unfinished, nextSprintID, userID); err != nil {
+		if errors.Is(err, repository.ErrSprintStateChanged) {
+			return models.Sprint{}, ErrSprintNotActive
+		}
+		return models.Sprint{}, err
+	}
+
+	if err := loadSprintSummary(db, &sprint, true); err != nil {
+		return models.Sprint{}, err
+	}
+	return sprint, nil
+}
+
+// AddSprintTasksService memasukkan task project ke sprint yang belum ditutup. Task dari sprint closed
+// tidak dapat dipindah agar snapshot sprint tersebut tetap sesuai dengan task-nya.
+func AddSprintTasksService(db *gorm.DB, project *models.Project, sprintID uint, taskIDs []uint, userID uint) (models.Sprint, error) {
+	if err := policy.Authorize(userID, policy.UpdateTask, project); err != nil {
+		return models.Sprint{}, ErrTaskUpdateDenied
+	}
+	if err := checkWritable(project); err != nil {
+		return models.Sprint{}, err
+	}
+	sprint, err := getSprint(db, project, sprintID)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	if sprint.State == models.SprintClosed {
+		return models.Sprint{}, ErrSprintClosed
+	}
+
+	taskIDs = uniqueIDs(taskIDs)
+	count, err := repository.CountProjectTasks(db, project.ID, taskIDs)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	if count != int64(len(taskIDs)) {
+		return models.Sprint{}, ErrTaskNotFound
+	}
+	closed, err := repository.CountTasksInClosedSprints(db, taskIDs)
+	if err != nil {
+		return models.Sprint{}, err
+	}
+	if closed > 0 {
+		return models.Sprint{}, ErrTaskInClosedSprint
+	}
+
+	if err := repository.SetTasksSprint(db, project.ID, taskIDs, &sprint.ID, userID); err != nil {
+		return models.Sprint{}, err
+	}
+	if err := loadSprintSummary(db, &sprint, false); err != nil {
+		return models.Sprint{}, err
+	}
+	return sprint, nil
+}
+
+// RemoveSprintTaskService mengembalikan task dari sprint yang belum ditutup ke backlog
+func RemoveSprintTaskService(db *gorm.DB, project *models.Project, sprintID, taskID, userID uint) error {
+	if err := policy.Authorize(userID, policy.UpdateTask, project); err != nil {
+		return ErrTaskUpdateDenied
+	}
+	if err := checkWritable(project); err != nil {
+		return err
+	}
+	sprint, err := getSprint(db, project, sprintID)
+	if err != nil {
+		return err
+	}
+	if sprint.State == models.SprintClosed {
+		return ErrSprintClosed
+	}
+
+	task, err := getProjectTask(db, project, taskID)
+	if err != nil {
+		return err
+	}
+	if task.SprintID == nil || *task.SprintID != sprint.ID {
+		return ErrTaskNotInSprint
+	}
+	return repository.SetTasksSprint(db, project.ID, []uint{task.ID}, nil, userID)
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := []uint{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
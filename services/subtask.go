@@ -0,0 +1,228 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// validateParent memastikan parent ada di project yang sama, tidak membentuk siklus,
+// dan hierarki tidak melebihi models.MaxTaskDepth. task.ID bernilai 0 untuk task baru.
+// db harus berupa transaksi yang memegang repository.LockTaskHierarchy sampai parent_id disimpan.
+func validateParent(db *gorm.DB, task models.Task, parentID uint) error {
+	if parentID == task.ID {
+		return ErrTaskCycle
+	}
+	parent, err := repository.GetTaskByID(db, parentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrParentNotFound
+		}
+		return err
+	}
+	if parent.ProjectID != task.ProjectID {
+		return ErrInvalidParent
+	}
+
+	lineage, err := repository.GetTaskLineage(db, parentID)
+	if err != nil {
+		return err
+	}
+	for _, id := range lineage {
+		if id == task.ID {
+			return ErrTaskCycle
+		}
+	}
+
+	height := 0
+	if task.ID != 0 {
+		if height, err = repository.GetSubtreeHeight(db, task.ID); err != nil {
+			return err
+		}
+	}
+	if len(lineage)+1+height > models.MaxTaskDepth {
+		return ErrTaskTooDeep
+	}
+	return nil
+}
+
+// SetTaskParentService memindahkan task ke bawah parent lain, atau menjadikannya task utama jika parentID nil
+func SetTaskParentService(db *gorm.DB, taskID uint, parentID *uint, expectedVersion uint, userID uint) (models.Task, error) {
+	task, err := getTask(db, taskID)
+	if err != nil {
+		return models.Task{}, err
+	}
+	if err := policy.Authorize(userID, policy.UpdateTask, &task); err != nil {
+		return models.Task{}, ErrTaskUpdateDenied
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return models.Task{}, err
+	}
+	if err := checkVersion(task.Version, expectedVersion); err != nil {
+		return models.Task{}, err
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := repository.LockTaskHierarchy(tx, task.ProjectID); err != nil {
+			return err
+		}
+		if parentID != nil {
+			if err := validateParent(tx, task, *parentID); err != nil {
+				return err
+			}
+		}
+		task.ParentID = parentID
+		return repository.UpdateTask(tx, &task, []string{"parent_id"}, nil, userID)
+	})
+	if err != nil {
+		return models.Task{}, versionError(err, expectedVersion)
+	}
+	mapAssignments(&task)
+	return task, nil
+}
+
+// loadTaskDetails mengisi subtask yang boleh dilihat user, checklist, dependency, dan progress task
+func loadTaskDetails(db *gorm.DB, task *models.Task, userID uint) error {
+	subtasks, err := repository.GetSubtasks(db, task.ID)
+	if err != nil {
+		return err
+	}
+	checklist, err := repository.GetChecklist(db, task.ID)
+	if err != nil {
+		return err
+	}
+	workflow, err := repository.GetWorkflow(db, task.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	if err := loadTaskLinks(db, task, userID); err != nil {
+		return err
+	}
+	if task.Labels, err = repository.GetTaskLabels(db, task.ID); err != nil {
+		return err
+	}
+
+	task.Progress = taskProgress(workflow, subtasks, checklist)
+	task.Checklist = checklist
+	task.Subtasks = []models.Task{}
+	for i := range subtasks {
+		subtasks[i].Project = task.Project
+		if !policy.Can(userID, policy.ViewTask, &subtasks[i]) {
+			continue
+		}
+		mapAssignments(&subtasks[i])
+		subtasks[i].Project = models.Project{}
+		task.Subtasks = append(task.Subtasks, subtasks[i])
+	}
+	return nil
+}
+
+// taskProgress menghitung progress dari subtask berkategori done, atau dari checklist jika tidak ada subtask
+func taskProgress(workflow models.Workflow, subtasks []models.Task, checklist []models.ChecklistItem) *models.TaskProgress {
+	progress := &models.TaskProgress{
+		SubtasksTotal:  len(subtasks),
+		ChecklistTotal: len(checklist),
+	}
+	for _, subtask := range subtasks {
+		if workflow.CategoryOf(subtask.Status) == models.StatusCategoryDone {
+			progress.SubtasksDone++
+		}
+	}
+	for _, item := range checklist {
+		if item.Done {
+			progress.ChecklistDone++
+		}
+	}
+
+	switch {
+	case progress.SubtasksTotal > 0:
+		progress.Percent = progress.SubtasksDone * 100 / progress.SubtasksTotal
+	case progress.ChecklistTotal > 0:
+		progress.Percent = progress.ChecklistDone * 100 / progress.ChecklistTotal
+	}
+	return progress
+}
+
+// getEditableTask memuat task dan memastikan user boleh mengubahnya dan project-nya tidak diarsipkan
+func getEditableTask(db *gorm.DB, taskID, userID uint) (models.Task, error) {
+	task, err := getTask(db, taskID)
+	if err != nil {
+		return models.Task{}, err
+	}
+	if !policy.Can(userID, policy.UpdateTask, &task) {
+		return models.Task{}, ErrTaskUpdateDenied
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return models.Task{}, err
+	}
+	return task, nil
+}
+
+func getChecklistItem(db *gorm.DB, taskID, itemID uint) (models.ChecklistItem, error) {
+	item, err := repository.GetChecklistItem(db, taskID, itemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.ChecklistItem{}, ErrChecklistItemNotFound
+		}
+		return models.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+func AddChecklistItemService(db *gorm.DB, taskID uint, title string, userID uint) (models.ChecklistItem, error) {
+	if _, err := getEditableTask(db, taskID, userID); err != nil {
+		return models.ChecklistItem{}, err
+	}
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return models.ChecklistItem{}, ErrEmptyChecklistTitle
+	}
+
+	item := models.ChecklistItem{TaskID: taskID, Title: title}
+	if err := repository.CreateChecklistItem(db, &item); err != nil {
+		return models.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+// UpdateChecklistItemService mengubah judul dan/atau status centang item, field nil tidak diubah
+func UpdateChecklistItemService(db *gorm.DB, taskID, itemID uint, title *string, done *bool, userID uint) (models.ChecklistItem, error) {
+	if _, err := getEditableTask(db, taskID, userID); err != nil {
+		return models.ChecklistItem{}, err
+	}
+	item, err := getChecklistItem(db, taskID, itemID)
+	if err != nil {
+		return models.ChecklistItem{}, err
+	}
+
+	if title != nil {
+		item.Title = strings.TrimSpace(*title)
+		if item.Title == "" {
+			return models.ChecklistItem{}, ErrEmptyChecklistTitle
+		}
+	}
+	if done != nil {
+		item.Done = *done
+	}
+
+	if err := repository.UpdateChecklistItem(db, &item); err != nil {
+		return models.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+func DeleteChecklistItemService(db *gorm.DB, taskID, itemID uint, userID uint) error {
+	if _, err := getEditableTask(db, taskID, userID); err != nil {
+		return err
+	}
+	err := repository.DeleteChecklistItem(db, taskID, itemID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrChecklistItemNotFound
+	}
+	return err
+}
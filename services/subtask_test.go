@@ -0,0 +1,77 @@
+package services
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"PA/internal/fakedb"
+)
+
+func TestSetTaskParentServiceLocksHierarchy(t *testing.T) {
+	tests := []struct {
+		name    string
+		lineage []int64 // parent 20 beserta seluruh parent-nya
+		want    error
+		steps   []string
+	}{
+		{
+			name:    "pindah ke parent lain",
+			lineage: []int64{20},
+			steps:   []string{"BEGIN", "lock", "lineage", "height", "update", "COMMIT"},
+		},
+		{
+			name:    "parent adalah subtask task itu sendiri",
+			lineage: []int64{20, 10},
+			want:    ErrTaskCycle,
+			steps:   []string{"BEGIN", "lock", "lineage", "ROLLBACK"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var steps []string
+			db, _ := fakedb.Open(t, func(query string, args []driver.NamedValue) fakedb.Result {
+				query = strings.TrimSpace(query)
+				switch {
+				case query == "BEGIN" || query == "COMMIT" || query == "ROLLBACK":
+					steps = append(steps, query)
+				case strings.HasPrefix(query, "SELECT pg_advisory_xact_lock"):
+					steps = append(steps, "lock")
+				case strings.HasPrefix(query, "WITH RECURSIVE lineage"):
+					steps = append(steps, "lineage")
+					result := fakedb.Result{Columns: []string{"id"}}
+					for _, id := range tt.lineage {
+						result.Rows = append(result.Rows, []driver.Value{id})
+					}
+					return result
+				case strings.HasPrefix(query, "WITH RECURSIVE tree"):
+					steps = append(steps, "height")
+					return fakedb.Result{Columns: []string{"coalesce"}, Rows: [][]driver.Value{{int64(0)}}}
+				case strings.HasPrefix(query, `SELECT * FROM "tasks"`):
+					return fakedb.Result{
+						Columns: []string{"id", "project_id", "title", "status", "version"},
+						Rows:    [][]driver.Value{{args[0].Value, int64(3), "Login", "todo", int64(4)}},
+					}
+				case strings.HasPrefix(query, `SELECT * FROM "projects"`):
+					return fakedb.Result{Columns: []string{"id", "owner_id"}, Rows: [][]driver.Value{{int64(3), int64(1)}}}
+				case strings.HasPrefix(query, `UPDATE "tasks"`):
+					steps = append(steps, "update")
+					return fakedb.Result{RowsAffected: 1}
+				}
+				return fakedb.Result{}
+			})
+
+			parentID := uint(20)
+			_, err := SetTaskParentService(db, 10, &parentID, 4, 1)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("SetTaskParentService() error = %v, want %v", err, tt.want)
+			}
+			if !reflect.DeepEqual(steps, tt.steps) {
+				t.Errorf("urutan statement = %v, want %v", steps, tt.steps)
+			}
+		})
+	}
+}
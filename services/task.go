@@ -3,7 +3,9 @@ package services
 import (
     "errors"
     "PA/models"
+    "PA/policy"
     "PA/repository"
+    "time"
     "gorm.io/gorm"
 )
 
@@ -18,173 +20,335 @@ func mapAssignments(task *models.Task) {
     }
 }
 
-func GetAllTasksService(db *gorm.DB, userID uint) ([]models.Task, error) {
-    tasks, err := repository.GetAllTask(db, userID)
+// getTask memuat task dan menerjemahkan record not found menjadi ErrTaskNotFound
+func getTask(db *gorm.DB, id uint) (models.Task, error) {
+    task, err := repository.GetTaskByID(db, id)
     if err != nil {
-        return nil, err
+        if errors.Is(err, gorm.ErrRecordNotFound) {
+            return models.Task{}, ErrTaskNotFound
+        }
+        return models.Task{}, err
+    }
+    return task, nil
+}
+
+// GetAllTasksService mengembalikan task dari semua project user dengan aturan yang sama seperti
+// GetTaskByProjectService: role tanpa ViewAllTasks (guest) hanya melihat task yang di-assign
+func GetAllTasksService(db *gorm.DB, userID uint, filter models.TaskFilter, opts models.ListOptions) ([]models.Task, string, error) {
+    tasks, next, err := repository.GetAllTask(db, userID, policy.RolesWith(policy.ViewAllTasks), filter, opts)
+    if err != nil {
+        return nil, "", listError(err)
     }
     for i := range tasks {
         mapAssignments(&tasks[i])
     }
-    return tasks, nil
+    return tasks, next, nil
 }
 
 func GetTaskByIDService(db *gorm.DB, id, userID uint) (models.Task, error) {
-    task, err := repository.GetTaskByID(db, id)
+    task, err := getTask(db, id)
     if err != nil {
         return models.Task{}, err
     }
 
-    if task.Project.OwnerID != userID {
-        isAssigned := false
-        for _, assignment := range task.Assignments {
-            if assignment.UserID == userID {
-                isAssigned = true
-                break
-            }
-        }
-        if !isAssigned {
-            return models.Task{}, errors.New("unauthorized access")
-        }
+    if err := policy.Authorize(userID, policy.ViewTask, &task); err != nil {
+        return models.Task{}, ErrTaskAccessDenied
+    }
+    if err := loadTaskDetails(db, &task, userID); err != nil {
+        return models.Task{}, err
     }
     
     mapAssignments(&task)
     return task, nil
 }
 
-func GetTaskByProjectService(db *gorm.DB, projectID, userID uint) ([]models.Task, error) {
-    project, err := repository.GetProjectByID(db, projectID, userID)
-    if err != nil {
-        return nil, err
+func GetTaskByProjectService(db *gorm.DB, project *models.Project, userID uint, filter models.TaskFilter, opts models.ListOptions) ([]models.Task, string, error) {
+    if err := policy.Authorize(userID, policy.ListTasks, project); err != nil {
+        return nil, "", ErrProjectAccessDenied
     }
 
-    isAuthorized := project.OwnerID == userID
-    if !isAuthorized {
-        for _, collab := range project.Collaborators {
-            if collab.UserID == userID {
-                isAuthorized = true
-                break
-            }
+    // Role yang tidak boleh melihat semua task (guest) hanya mendapat task yang di-assign ke dirinya
+    if !policy.Can(userID, policy.ViewAllTasks, project) {
+        if filter.AssigneeID != 0 && filter.AssigneeID != userID {
+            return []models.Task{}, "", nil
         }
+        filter.AssigneeID = userID
     }
-    if !isAuthorized {
-        return nil, errors.New("unauthorized access")
+
+    tasks, next, err := repository.GetTaskByProject(db, project.ID, filter, opts)
+    if err != nil {
+        return nil, "", listError(err)
     }
 
-    tasks, err := repository.GetTaskByProject(db, projectID)
     for i := range tasks {
+        tasks[i].Project = *project
         mapAssignments(&tasks[i])
     }
-    return tasks, err
+    return tasks, next, nil
 }
 
-func validateUsersInProject(db *gorm.DB, projectID uint, userIDs []uint) error {
-    project, err := repository.GetProjectByID(db, projectID, 0)
-    if err != nil {
-        return err
-    }
-
-    validUsers := map[uint]bool{project.OwnerID: true}
-    for _, collab := range project.Collaborators {
-        validUsers[collab.UserID] = true
-    }
-
+func validateUsersInProject(project *models.Project, userIDs []uint) error {
     for _, uid := range userIDs {
-        if !validUsers[uid] {
-            return errors.New("invalid user assignment")
+        if !policy.IsMember(project, uid) {
+            return ErrInvalidAssignment
         }
     }
     return nil
 }
 
-func CreateTaskService(db *gorm.DB, projectID uint, task *models.Task, userIDs []uint, currentUserID uint) error {
-    var project models.Project
-    if err := db.First(&project, projectID).Error; err != nil {
-        return errors.New("project tidak ditemukan")
+func CreateTaskService(db *gorm.DB, project *models.Project, task *models.Task, userIDs []uint, currentUserID uint) error {
+    if err := policy.Authorize(currentUserID, policy.CreateTask, project); err != nil {
+        return ErrTaskCreateDenied
+    }
+    if err := checkWritable(project); err != nil {
+        return err
     }
 
-    isOwner, err := repository.IsOwner(db, projectID, currentUserID)
-    if err != nil {
+    if err := validateUsersInProject(project, userIDs); err != nil {
         return err
     }
     
-    isCollaborator := false
-    if !isOwner {
-        project, _ := repository.GetProjectByID(db, projectID, 0)
-        for _, collab := range project.Collaborators {
-            if collab.UserID == currentUserID {
-                isCollaborator = true
-                break
-            }
-        }
+    workflow, err := repository.GetWorkflow(db, project.ID)
+    if err != nil {
+        return err
     }
-    
-    if !isOwner && !isCollaborator {
-        return errors.New("hanya owner/collaborator yang bisa membuat task")
+    status, err := resolveTaskStatus(workflow, "", task.Status)
+    if err != nil {
+        return err
+    }
+    task.Status = status.Name
+    if status.Category == models.StatusCategoryDone {
+        now := time.Now()
+        task.CompletedAt = &now
     }
 
-    if err := validateUsersInProject(db, projectID, userIDs); err != nil {
+    if task.DurationDays < 0 {
+        return ErrInvalidDuration
+    }
+    if err := validateEstimate(task.StoryPoints, task.EstimatedHours); err != nil {
         return err
     }
-    
-    task.ProjectID = projectID
-    if err := repository.CreateTask(db, task, userIDs); err != nil {
+
+    task.ProjectID = project.ID
+    err = db.Transaction(func(tx *gorm.DB) error {
+        if task.ParentID != nil {
+            if err := repository.LockTaskHierarchy(tx, project.ID); err != nil {
+                return err
+            }
+            if err := validateParent(tx, *task, *task.ParentID); err != nil {
+                return err
+            }
+        }
+        return repository.CreateTask(tx, task, userIDs, currentUserID)
+    })
+    if err != nil {
         return err
     }
     mapAssignments(task)
     return nil
 }
 
-func UpdateTaskService(db *gorm.DB, projectID, taskID uint, task *models.Task, userIDs []uint, userID uint) error {
-    isOwner, err := repository.IsOwner(db, projectID, userID)
+// UpdateTaskService menerapkan patch ke task. Dipakai oleh PUT (semua field) maupun PATCH (sebagian field).
+// expectedVersion berasal dari header If-Match, 0 jika tidak dikirim.
+func UpdateTaskService(db *gorm.DB, project *models.Project, taskID uint, patch models.TaskPatch, expectedVersion uint, userID uint) (models.Task, error) {
+    task, err := getProjectTask(db, project, taskID)
     if err != nil {
-        return err
+        return models.Task{}, err
     }
-    
-    isCollaborator := false
-    if !isOwner {
-        project, _ := repository.GetProjectByID(db, projectID, 0)
-        for _, collab := range project.Collaborators {
-            if collab.UserID == userID {
-                isCollaborator = true
-                break
+
+    if err := policy.Authorize(userID, policy.UpdateTask, &task); err != nil {
+        return models.Task{}, ErrTaskUpdateDenied
+    }
+    if err := checkWritable(&task.Project); err != nil {
+        return models.Task{}, err
+    }
+    if err := checkVersion(task.Version, expectedVersion); err != nil {
+        return models.Task{}, err
+    }
+
+    fields := []string{}
+    if patch.Title != nil {
+        task.Title = *patch.Title
+        fields = append(fields, "title")
+    }
+    if patch.Description != nil {
+        task.Description = *patch.Description
+        fields = append(fields, "description")
+    }
+    if patch.Deadline != nil {
+        task.Deadline = *patch.Deadline
+        fields = append(fields, "deadline")
+    }
+    if patch.DurationDays != nil {
+        if *patch.DurationDays < 0 {
+            return models.Task{}, ErrInvalidDuration
+        }
+        task.DurationDays = *patch.DurationDays
+        fields = append(fields, "duration_days")
+    }
+    if patch.StoryPoints != nil {
+        task.StoryPoints = *patch.StoryPoints
+        fields = append(fields, "story_points")
+    }
+    if patch.EstimatedHours != nil {
+        task.EstimatedHours = *patch.EstimatedHours
+        fields = append(fields, "estimated_hours")
+    }
+    if err := validateEstimate(task.StoryPoints, task.EstimatedHours); err != nil {
+        return models.Task{}, err
+    }
+    if patch.Status != nil {
+        workflow, err := repository.GetWorkflow(db, project.ID)
+        if err != nil {
+            return models.Task{}, err
+        }
+        status, err := resolveTaskStatus(workflow, task.Status, *patch.Status)
+        if err != nil {
+            return models.Task{}, err
+        }
+        wasDone := workflow.CategoryOf(task.Status) == models.StatusCategoryDone
+        isDone := status.Category == models.StatusCategoryDone
+        if isDone && !wasDone {
+            if err := checkBlockers(db, task.ID, userID); err != nil {
+                return models.Task{}, err
             }
+            now := time.Now()
+            task.CompletedAt = &now
+            fields = append(fields, "completed_at")
+        }
+        if !isDone && wasDone {
+            task.CompletedAt = nil
+            fields = append(fields, "completed_at")
         }
+        task.Status = status.Name
+        fields = append(fields, "status")
     }
-    
-    if !isOwner && !isCollaborator {
-        return errors.New("unauthorized access")
+
+    var userIDs []uint
+    if patch.AssignedTo != nil {
+        userIDs = *patch.AssignedTo
+        if userIDs == nil {
+            userIDs = []uint{}
+        }
+        if err := validateUsersInProject(project, userIDs); err != nil {
+            return models.Task{}, err
+        }
     }
 
-    if err := validateUsersInProject(db, projectID, userIDs); err != nil {
-        return err
+    if err := repository.UpdateTask(db, &task, fields, userIDs, userID); err != nil {
+        return models.Task{}, versionError(err, expectedVersion)
     }
     
-    task.ID = taskID
-    task.ProjectID = projectID
-    
-    if err := repository.UpdateTask(db, task, userIDs); err != nil {
-        return err
+    mapAssignments(&task)
+    return task, nil
+}
+
+// validateEstimate memastikan story point dan jam estimasi tidak negatif
+func validateEstimate(storyPoints *int, estimatedHours *float64) error {
+    if storyPoints != nil && *storyPoints < 0 {
+        return ErrInvalidEstimate
+    }
+    if estimatedHours != nil && *estimatedHours < 0 {
+        return ErrInvalidEstimate
     }
-    
-    mapAssignments(task)
     return nil
 }
 
-func DeleteTaskService(db *gorm.DB, id uint, userID uint) error {
-    task, err := repository.GetTaskByID(db, id)
+func AddTaskAssigneesService(db *gorm.DB, project *models.Project, taskID uint, userIDs []uint, expectedVersion uint, userID uint) (models.Task, error) {
+    task, err := getProjectTask(db, project, taskID)
     if err != nil {
-        return err
+        return models.Task{}, err
     }
-    
-    isOwner, err := repository.IsOwner(db, task.ProjectID, userID)
+
+    if err := policy.Authorize(userID, policy.UpdateTask, &task); err != nil {
+        return models.Task{}, ErrTaskUpdateDenied
+    }
+    if err := checkWritable(&task.Project); err != nil {
+        return models.Task{}, err
+    }
+    if err := checkVersion(task.Version, expectedVersion); err != nil {
+        return models.Task{}, err
+    }
+    if err := validateUsersInProject(project, userIDs); err != nil {
+        return models.Task{}, err
+    }
+
+    if err := repository.UpdateTask(db, &task, nil, append(assignedUserIDs(task), userIDs...), userID); err != nil {
+        return models.Task{}, versionError(err, expectedVersion)
+    }
+
+    mapAssignments(&task)
+    return task, nil
+}
+
+func RemoveTaskAssigneeService(db *gorm.DB, project *models.Project, taskID, assigneeID uint, expectedVersion uint, userID uint) (models.Task, error) {
+    task, err := getProjectTask(db, project, taskID)
+    if err != nil {
+        return models.Task{}, err
+    }
+
+    if err := policy.Authorize(userID, policy.UpdateTask, &task); err != nil {
+        return models.Task{}, ErrTaskUpdateDenied
+    }
+    if err := checkWritable(&task.Project); err != nil {
+        return models.Task{}, err
+    }
+    if err := checkVersion(task.Version, expectedVersion); err != nil {
+        return models.Task{}, err
+    }
+    if !policy.IsAssigned(&task, assigneeID) {
+        return models.Task{}, ErrAssigneeNotFound
+    }
+
+    remaining := []uint{}
+    for _, id := range assignedUserIDs(task) {
+        if id != assigneeID {
+            remaining = append(remaining, id)
+        }
+    }
+    if err := repository.UpdateTask(db, &task, nil, remaining, userID); err != nil {
+        return models.Task{}, versionError(err, expectedVersion)
+    }
+
+    mapAssignments(&task)
+    return task, nil
+}
+
+func assignedUserIDs(task models.Task) []uint {
+    ids := make([]uint, len(task.Assignments))
+    for i, assignment := range task.Assignments {
+        ids[i] = assignment.UserID
+    }
+    return ids
+}
+
+// getProjectTask memuat task dan memastikan task tersebut milik project di url
+func getProjectTask(db *gorm.DB, project *models.Project, taskID uint) (models.Task, error) {
+    task, err := getTask(db, taskID)
+    if err != nil {
+        return models.Task{}, err
+    }
+    if task.ProjectID != project.ID {
+        return models.Task{}, ErrTaskNotFound
+    }
+    return task, nil
+}
+
+func DeleteTaskService(db *gorm.DB, id uint, expectedVersion uint, userID uint) error {
+    task, err := getTask(db, id)
     if err != nil {
         return err
     }
     
-    if !isOwner {
-        return errors.New("unauthorized: hanya owner yang bisa menghapus task")
+    if err := policy.Authorize(userID, policy.DeleteTask, &task); err != nil {
+        return ErrTaskDeleteDenied
+    }
+    if err := checkWritable(&task.Project); err != nil {
+        return err
+    }
+    if err := checkVersion(task.Version, expectedVersion); err != nil {
+        return err
     }
     
-    return repository.DeleteTask(db, id)
+    return versionError(repository.DeleteTask(db, id, task.Version, userID), expectedVersion)
 }
\ No newline at end of file
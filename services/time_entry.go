@@ -0,0 +1,220 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// canLogTime: waktu boleh dicatat oleh user yang di-assign ke task atau anggota project yang boleh mengubah task
+func canLogTime(userID uint, task *models.Task) bool {
+	return policy.IsAssigned(task, userID) || policy.Can(userID, policy.UpdateTask, task)
+}
+
+func getLoggableTask(db *gorm.DB, taskID, userID uint) (models.Task, error) {
+	task, err := getTask(db, taskID)
+	if err != nil {
+		return models.Task{}, err
+	}
+	if !canLogTime(userID, &task) {
+		return models.Task{}, ErrTimeTrackingDenied
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return models.Task{}, err
+	}
+	return task, nil
+}
+
+// StartTimerService memulai timer user pada task. Ditolak jika user masih memiliki timer yang berjalan.
+func StartTimerService(db *gorm.DB, taskID uint, note string, userID uint) (models.TimeEntry, error) {
+	task, err := getLoggableTask(db, taskID, userID)
+	if err != nil {
+		return models.TimeEntry{}, err
+	}
+
+	running, err := repository.GetRunningTimer(db, userID)
+	if err == nil {
+		return models.TimeEntry{}, ErrTimerRunning.WithDetail("running_timer", running)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.TimeEntry{}, err
+	}
+
+	entry := models.TimeEntry{
+		TaskID:    task.ID,
+		UserID:    userID,
+		StartedAt: time.Now(),
+		Note:      note,
+		Source:    models.TimeSourceTimer,
+	}
+	if err := repository.CreateTimeEntry(db, &entry); err != nil {
+		// unique index idx_running_timer menangkap dua start yang bersamaan
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return models.TimeEntry{}, ErrTimerRunning
+		}
+		return models.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// StopTimerService menghentikan timer user yang berjalan pada task
+func StopTimerService(db *gorm.DB, taskID uint, userID uint) (models.TimeEntry, error) {
+	entry, err := repository.GetRunningTimer(db, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && entry.TaskID != taskID) {
+		return models.TimeEntry{}, ErrNoRunningTimer
+	}
+	if err != nil {
+		return models.TimeEntry{}, err
+	}
+
+	now := time.Now()
+	entry.EndedAt = &now
+	entry.DurationSeconds = int64(now.Sub(entry.StartedAt) / time.Second)
+	if err := repository.StopTimer(db, &entry); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.TimeEntry{}, ErrNoRunningTimer
+		}
+		return models.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// GetRunningTimerService mengembalikan timer user yang sedang berjalan, nil jika tidak ada
+func GetRunningTimerService(db *gorm.DB, userID uint) (*models.TimeEntry, error) {
+	entry, err := repository.GetRunningTimer(db, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// completeEntry memvalidasi entry manual. EndedAt boleh kosong jika DurationSeconds diisi, dan sebaliknya.
+func completeEntry(entry *models.TimeEntry) error {
+	if entry.StartedAt.IsZero() {
+		return ErrInvalidTimeEntry
+	}
+	if entry.EndedAt != nil {
+		if !entry.EndedAt.After(entry.StartedAt) {
+			return ErrInvalidTimeEntry
+		}
+		entry.DurationSeconds = int64(entry.EndedAt.Sub(entry.StartedAt) / time.Second)
+		return nil
+	}
+	if entry.DurationSeconds <= 0 {
+		return ErrInvalidTimeEntry
+	}
+	ended := entry.StartedAt.Add(time.Duration(entry.DurationSeconds) * time.Second)
+	entry.EndedAt = &ended
+	return nil
+}
+
+func AddTimeEntryService(db *gorm.DB, taskID uint, entry models.TimeEntry, userID uint) (models.TimeEntry, error) {
+	task, err := getLoggableTask(db, taskID, userID)
+	if err != nil {
+		return models.TimeEntry{}, err
+	}
+	if err := completeEntry(&entry); err != nil {
+		return models.TimeEntry{}, err
+	}
+
+	entry.ID = 0
+	entry.TaskID = task.ID
+	entry.UserID = userID
+	entry.Source = models.TimeSourceManual
+	if err := repository.CreateTimeEntry(db, &entry); err != nil {
+		return models.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func getOwnTimeEntry(db *gorm.DB, taskID, entryID, userID uint) (models.TimeEntry, error) {
+	entry, err := repository.GetTimeEntry(db, taskID, entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.TimeEntry{}, ErrTimeEntryNotFound
+		}
+		return models.TimeEntry{}, err
+	}
+	if entry.UserID != userID {
+		return models.TimeEntry{}, ErrTimeTrackingDenied
+	}
+	return entry, nil
+}
+
+// UpdateTimeEntryService mengubah entry milik sendiri yang sudah selesai
+func UpdateTimeEntryService(db *gorm.DB, taskID, entryID uint, changes models.TimeEntry, userID uint) (models.TimeEntry, error) {
+	if _, err := getLoggableTask(db, taskID, userID); err != nil {
+		return models.TimeEntry{}, err
+	}
+	entry, err := getOwnTimeEntry(db, taskID, entryID, userID)
+	if err != nil {
+		return models.TimeEntry{}, err
+	}
+	if entry.EndedAt == nil {
+		return models.TimeEntry{}, ErrTimerRunning
+	}
+
+	entry.StartedAt = changes.StartedAt
+	entry.EndedAt = changes.EndedAt
+	entry.DurationSeconds = changes.DurationSeconds
+	entry.Note = changes.Note
+	if err := completeEntry(&entry); err != nil {
+		return models.TimeEntry{}, err
+	}
+	if err := repository.UpdateTimeEntry(db, &entry); err != nil {
+		return models.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// DeleteTimeEntryService menghapus entry milik sendiri, owner/admin boleh menghapus entry siapa saja
+func DeleteTimeEntryService(db *gorm.DB, taskID, entryID, userID uint) error {
+	task, err := getTask(db, taskID)
+	if err != nil {
+		return err
+	}
+	entry, err := repository.GetTimeEntry(db, taskID, entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTimeEntryNotFound
+		}
+		return err
+	}
+	if entry.UserID != userID && !policy.Can(userID, policy.DeleteTask, &task) {
+		return ErrTimeTrackingDenied
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return err
+	}
+	return repository.DeleteTimeEntry(db, entry.ID)
+}
+
+func GetTaskTimeService(db *gorm.DB, taskID, userID uint, timeRange models.TimeRange) ([]models.TimeEntry, models.TimeSummary, error) {
+	if _, err := getReadableTask(db, taskID, userID); err != nil {
+		return nil, models.TimeSummary{}, err
+	}
+	entries, err := repository.GetTaskTimeEntries(db, taskID, timeRange)
+	if err != nil {
+		return nil, models.TimeSummary{}, err
+	}
+	summary, err := repository.GetTaskTimeSummary(db, taskID, timeRange)
+	return entries, summary, err
+}
+
+func GetProjectTimeService(db *gorm.DB, project *models.Project, userID uint, timeRange models.TimeRange) (models.TimeSummary, error) {
+	if err := policy.Authorize(userID, policy.ViewAllTasks, project); err != nil {
+		return models.TimeSummary{}, ErrReportDenied
+	}
+	return repository.GetProjectTimeSummary(db, project.ID, timeRange)
+}
+
+func GetMyTimeService(db *gorm.DB, userID uint, timeRange models.TimeRange) (models.TimeSummary, error) {
+	return repository.GetUserTimeSummary(db, userID, timeRange)
+}
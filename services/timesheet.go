@@ -0,0 +1,106 @@
+package services
+
+import (
+	"PA/export"
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"math"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var timesheetHeader = []interface{}{"Date", "User", "Project", "Task", "Started At", "Ended At", "Hours", "Note"}
+
+// ProjectTimesheetFilter memeriksa akses laporan project dan membentuk filter timesheet-nya.
+// Dipanggil sebelum response mulai ditulis agar error masih bisa dikembalikan sebagai problem+json.
+func ProjectTimesheetFilter(project *models.Project, userID uint, timeRange models.TimeRange, groupBy string) (models.TimesheetFilter, error) {
+	if err := policy.Authorize(userID, policy.ViewAllTasks, project); err != nil {
+		return models.TimesheetFilter{}, ErrReportDenied
+	}
+	return timesheetFilter(models.TimesheetFilter{ProjectID: project.ID, Range: timeRange, GroupBy: groupBy})
+}
+
+// UserTimesheetFilter membentuk filter timesheet berisi waktu kerja user sendiri di semua project
+func UserTimesheetFilter(userID uint, timeRange models.TimeRange, groupBy string) (models.TimesheetFilter, error) {
+	return timesheetFilter(models.TimesheetFilter{UserID: userID, Range: timeRange, GroupBy: groupBy})
+}
+
+func timesheetFilter(filter models.TimesheetFilter) (models.TimesheetFilter, error) {
+	if filter.GroupBy == "" {
+		filter.GroupBy = models.TimesheetGroupDay
+	}
+	if !models.IsValidTimesheetGroup(filter.GroupBy) {
+		return models.TimesheetFilter{}, ErrInvalidTimesheetGroup
+	}
+	return filter, nil
+}
+
+// WriteTimesheetService menulis timesheet ke w baris demi baris. Setiap kelompok (hari, task atau user)
+// diakhiri baris subtotal dan file ditutup dengan baris total. Tanggal ditulis dalam zona waktu loc.
+func WriteTimesheetService(db *gorm.DB, filter models.TimesheetFilter, loc *time.Location, w export.Writer) error {
+	if err := w.WriteRow(timesheetHeader...); err != nil {
+		return err
+	}
+
+	var group, label string
+	var groupSeconds, totalSeconds int64
+	flush := func() error {
+		if group == "" {
+			return nil
+		}
+		return w.WriteRow("Subtotal "+label, "", "", "", "", "", hours(groupSeconds))
+	}
+
+	err := repository.StreamTimesheet(db, filter, func(row models.TimesheetRow) error {
+		key, rowLabel := timesheetGroup(row, filter.GroupBy, loc)
+		if key != group {
+			if err := flush(); err != nil {
+				return err
+			}
+			group, label, groupSeconds = key, rowLabel, 0
+		}
+		groupSeconds += row.DurationSeconds
+		totalSeconds += row.DurationSeconds
+
+		return w.WriteRow(
+			row.StartedAt.In(loc).Format("2006-01-02"),
+			row.Username,
+			row.ProjectName,
+			row.TaskTitle,
+			row.StartedAt.In(loc).Format("2006-01-02 15:04"),
+			row.EndedAt.In(loc).Format("2006-01-02 15:04"),
+			hours(row.DurationSeconds),
+			row.Note,
+		)
+	})
+	if err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if err := w.WriteRow("Total", "", "", "", "", "", hours(totalSeconds)); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// timesheetGroup mengembalikan kunci kelompok sebuah baris beserta labelnya untuk baris subtotal
+func timesheetGroup(row models.TimesheetRow, groupBy string, loc *time.Location) (string, string) {
+	switch groupBy {
+	case models.TimesheetGroupTask:
+		return strconv.FormatUint(uint64(row.TaskID), 10), row.ProjectName + " / " + row.TaskTitle
+	case models.TimesheetGroupUser:
+		return strconv.FormatUint(uint64(row.UserID), 10), row.Username
+	}
+	day := row.StartedAt.In(loc).Format("2006-01-02")
+	return day, day
+}
+
+// hours mengubah detik menjadi jam dengan dua angka desimal
+func hours(seconds int64) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"PA/utils"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DefaultTrashRetentionDays dipakai jika TRASH_RETENTION_DAYS tidak diisi
+const DefaultTrashRetentionDays = 30
+
+// TrashRetention adalah lama item disimpan di trash sebelum dihapus permanen
+func TrashRetention() time.Duration {
+	return time.Duration(utils.Int64FromEnv("TRASH_RETENTION_DAYS", DefaultTrashRetentionDays)) * day
+}
+
+// GetTrashService mengembalikan project milik user dan task yang boleh dipulihkan user, terbaru dihapus dulu
+func GetTrashService(db *gorm.DB, userID uint) ([]models.TrashItem, error) {
+	projects, err := repository.GetTrashedProjects(db, userID)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := repository.GetTrashedTasks(db, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := projects
+	for _, task := range tasks {
+		if policy.Allows(task.Role, policy.DeleteTask) {
+			items = append(items, task)
+		}
+	}
+
+	retention := TrashRetention()
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.Add(retention)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].DeletedAt.After(items[j].DeletedAt) })
+	return items, nil
+}
+
+// RestoreProjectService memulihkan project dari trash beserta task yang dihapus bersamanya
+func RestoreProjectService(db *gorm.DB, projectID, userID uint) (models.Project, error) {
+	project, err := repository.GetTrashedProject(db, projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Project{}, ErrProjectNotFound
+		}
+		return models.Project{}, err
+	}
+	if err := policy.Authorize(userID, policy.DeleteProject, &project); err != nil {
+		return models.Project{}, ErrTrashRestoreDenied
+	}
+
+	if err := repository.RestoreProject(db, &project, userID); err != nil {
+		if errors.Is(err, repository.ErrStaleVersion) {
+			return models.Project{}, ErrProjectNotFound
+		}
+		return models.Project{}, err
+	}
+	return repository.GetProjectByID(db, project.ID, userID)
+}
+
+// RestoreTaskService memulihkan task dari trash beserta subtask yang dihapus bersamanya.
+// Project dan parent task harus sudah aktif, dan project tidak sedang diarsipkan.
+func RestoreTaskService(db *gorm.DB, taskID, userID uint) (models.Task, error) {
+	task, err := repository.GetTrashedTask(db, taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.Task{}, ErrTaskNotFound
+		}
+		return models.Task{}, err
+	}
+	if err := policy.Authorize(userID, policy.DeleteTask, &task); err != nil {
+		return models.Task{}, ErrTrashRestoreDenied
+	}
+	if task.Project.DeletedAt.Valid {
+		return models.Task{}, ErrRestoreProjectFirst
+	}
+	if err := checkWritable(&task.Project); err != nil {
+		return models.Task{}, err
+	}
+	if task.ParentID != nil {
+		trashed, err := repository.IsTaskTrashed(db, *task.ParentID)
+		if err != nil {
+			return models.Task{}, err
+		}
+		if trashed {
+			return models.Task{}, ErrRestoreParentFirst
+		}
+	}
+
+	if err := repository.RestoreTask(db, &task, userID); err != nil {
+		if errors.Is(err, repository.ErrStaleVersion) {
+			return models.Task{}, ErrTaskNotFound
+		}
+		return models.Task{}, err
+	}
+	mapAssignments(&task)
+	return task, nil
+}
+
+// PurgeTrash menghapus permanen item yang sudah melewati masa retensi trash
+func PurgeTrash(db *gorm.DB) error {
+	projects, tasks, err := repository.PurgeTrash(db, time.Now().Add(-TrashRetention()))
+	if projects > 0 || tasks > 0 {
+		log.Printf("trash: %d project dan %d task dihapus permanen", projects, tasks)
+	}
+	return err
+}
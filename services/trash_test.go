@@ -0,0 +1,54 @@
+package services
+
+import (
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"PA/internal/fakedb"
+)
+
+func TestRestoreTaskServiceArchivedProject(t *testing.T) {
+	tests := []struct {
+		name       string
+		archivedAt interface{}
+		want       error
+		restored   bool
+	}{
+		{"project aktif", nil, nil, true},
+		{"project diarsipkan", time.Now(), ErrProjectArchived, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deletedAt := time.Now().Add(-time.Hour)
+			db, fake := fakedb.Open(t, func(query string, _ []driver.NamedValue) fakedb.Result {
+				switch {
+				case strings.HasPrefix(query, `SELECT * FROM "tasks"`):
+					return fakedb.Result{
+						Columns: []string{"id", "project_id", "title", "status", "version", "deleted_at"},
+						Rows:    [][]driver.Value{{int64(10), int64(3), "Login", "todo", int64(4), deletedAt}},
+					}
+				case strings.HasPrefix(query, `SELECT * FROM "projects"`):
+					return fakedb.Result{
+						Columns: []string{"id", "owner_id", "archived_at"},
+						Rows:    [][]driver.Value{{int64(3), int64(1), tt.archivedAt}},
+					}
+				case strings.HasPrefix(query, `UPDATE "tasks"`):
+					return fakedb.Result{RowsAffected: 1}
+				}
+				return fakedb.Result{}
+			})
+
+			_, err := RestoreTaskService(db, 10, 1)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("RestoreTaskService() error = %v, want %v", err, tt.want)
+			}
+			if restored := len(fake.Queries(`UPDATE "tasks"`)) > 0; restored != tt.restored {
+				t.Errorf("task dipulihkan = %v, want %v", restored, tt.restored)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Batas report velocity agar jumlah window tetap wajar
+const (
+	DefaultVelocityWindowDays = 14
+	DefaultVelocityWindows    = 6
+	MaxVelocityWindows        = 104
+	MaxVelocityWindowDays     = 366
+)
+
+// GetProjectVelocityService menjumlahkan task yang selesai (berdasarkan completed_at) per window windowDays hari
+// dari from sampai to. Rata-rata dihitung dari window yang sudah lengkap saja.
+func GetProjectVelocityService(db *gorm.DB, project *models.Project, userID uint, from, to time.Time, windowDays int) (models.VelocityReport, error) {
+	if err := policy.Authorize(userID, policy.ViewAllTasks, project); err != nil {
+		return models.VelocityReport{}, ErrReportDenied
+	}
+
+	if windowDays <= 0 || windowDays > MaxVelocityWindowDays || !from.Before(to) {
+		return models.VelocityReport{}, ErrInvalidVelocityRange
+	}
+	window := time.Duration(windowDays) * day
+	if to.Sub(from) > MaxVelocityWindows*window {
+		return models.VelocityReport{}, ErrInvalidVelocityRange
+	}
+
+	windows, err := repository.GetVelocityWindows(db, project.ID, from, to, window)
+	if err != nil {
+		return models.VelocityReport{}, err
+	}
+
+	report := models.VelocityReport{
+		ProjectID:  project.ID,
+		From:       from,
+		To:         to,
+		WindowDays: windowDays,
+		By:         models.VelocityByWindow,
+		Windows:    windows,
+	}
+
+	var complete int
+	for _, w := range windows {
+		if w.End.Sub(w.Start) < window || w.End.After(time.Now()) {
+			continue
+		}
+		complete++
+		report.AveragePoints += float64(w.CompletedPoints)
+		report.AverageHours += w.CompletedHours
+		report.AverageTasks += float64(w.CompletedTasks)
+	}
+	if complete > 0 {
+		report.AveragePoints = round2(report.AveragePoints / float64(complete))
+		report.AverageHours = round2(report.AverageHours / float64(complete))
+		report.AverageTasks = round2(report.AverageTasks / float64(complete))
+	}
+	return report, nil
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// GetSprintVelocityService menjumlahkan task yang selesai per sprint closed yang berakhir di antara from dan to,
+// diambil dari snapshot saat sprint ditutup. Rata-rata dihitung dari seluruh sprint tersebut.
+func GetSprintVelocityService(db *gorm.DB, project *models.Project, userID uint, from, to time.Time) (models.VelocityReport, error) {
+	if err := policy.Authorize(userID, policy.ViewAllTasks, project); err != nil {
+		return models.VelocityReport{}, ErrReportDenied
+	}
+	if !from.Before(to) {
+		return models.VelocityReport{}, ErrInvalidVelocityRange
+	}
+
+	windows, err := repository.GetSprintVelocity(db, project.ID, from, to)
+	if err != nil {
+		return models.VelocityReport{}, err
+	}
+
+	report := models.VelocityReport{
+		ProjectID: project.ID,
+		From:      from,
+		To:        to,
+		By:        models.VelocityBySprint,
+		Windows:   windows,
+	}
+	for _, w := range windows {
+		report.AveragePoints += float64(w.CompletedPoints)
+		report.AverageHours += w.CompletedHours
+		report.AverageTasks += float64(w.CompletedTasks)
+	}
+	if len(windows) > 0 {
+		report.AveragePoints = round2(report.AveragePoints / float64(len(windows)))
+		report.AverageHours = round2(report.AverageHours / float64(len(windows)))
+		report.AverageTasks = round2(report.AverageTasks / float64(len(windows)))
+	}
+	return report, nil
+}
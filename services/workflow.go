@@ -0,0 +1,153 @@
+package services
+
+import (
+	"PA/models"
+	"PA/policy"
+	"PA/repository"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+func GetWorkflowService(db *gorm.DB, project *models.Project) (models.Workflow, error) {
+	return repository.GetWorkflow(db, project.ID)
+}
+
+func UpdateWorkflowService(db *gorm.DB, project *models.Project, input models.Workflow, userID uint) (models.Workflow, error) {
+	if err := policy.Authorize(userID, policy.ManageWorkflow, project); err != nil {
+		return models.Workflow{}, ErrWorkflowDenied
+	}
+	if err := checkWritable(project); err != nil {
+		return models.Workflow{}, err
+	}
+
+	current, err := repository.GetWorkflow(db, project.ID)
+	if err != nil {
+		return models.Workflow{}, err
+	}
+
+	if err := validateWorkflow(input); err != nil {
+		return models.Workflow{}, err
+	}
+
+	existing := make(map[uint]models.WorkflowStatus, len(current.Statuses))
+	for _, status := range current.Statuses {
+		existing[status.ID] = status
+	}
+
+	renames := make(map[string]string)
+	for i := range input.Statuses {
+		status := &input.Statuses[i]
+		status.Position = i
+		if status.ID == 0 {
+			continue
+		}
+		old, ok := existing[status.ID]
+		if !ok {
+			return models.Workflow{}, ErrInvalidWorkflow.WithDetail("reason", "status id tidak ditemukan di project ini")
+		}
+		if old.Name != status.Name {
+			renames[old.Name] = status.Name
+		}
+		delete(existing, status.ID)
+	}
+
+	// Status yang dihapus tidak boleh masih dipakai task
+	for _, removed := range existing {
+		count, err := repository.CountTasksWithStatus(db, project.ID, removed.Name)
+		if err != nil {
+			return models.Workflow{}, err
+		}
+		if count > 0 {
+			return models.Workflow{}, ErrStatusInUse.WithDetail("status", removed.Name)
+		}
+	}
+
+	if err := repository.SaveWorkflow(db, project.ID, input, renames); err != nil {
+		return models.Workflow{}, err
+	}
+	return repository.GetWorkflow(db, project.ID)
+}
+
+func validateWorkflow(workflow models.Workflow) error {
+	if len(workflow.Statuses) == 0 {
+		return ErrInvalidWorkflow.WithDetail("reason", "workflow harus memiliki minimal satu status")
+	}
+
+	names := make(map[string]bool, len(workflow.Statuses))
+	for i := range workflow.Statuses {
+		status := &workflow.Statuses[i]
+		status.Name = strings.TrimSpace(status.Name)
+		if status.Name == "" {
+			return ErrInvalidWorkflow.WithDetail("reason", "nama status tidak boleh kosong")
+		}
+		if !models.IsValidStatusCategory(status.Category) {
+			return ErrInvalidWorkflow.WithDetail("reason", "kategori status harus todo, in_progress atau done")
+		}
+		key := strings.ToLower(status.Name)
+		if names[key] {
+			return ErrInvalidWorkflow.WithDetail("reason", "nama status duplikat: "+status.Name)
+		}
+		names[key] = true
+	}
+
+	for i := range workflow.Transitions {
+		transition := &workflow.Transitions[i]
+		transition.From = strings.TrimSpace(transition.From)
+		transition.To = strings.TrimSpace(transition.To)
+		if !names[strings.ToLower(transition.From)] || !names[strings.ToLower(transition.To)] {
+			return ErrInvalidWorkflow.WithDetail("reason", "transisi memakai status yang tidak ada di workflow")
+		}
+	}
+	return nil
+}
+
+// resolveTaskStatus menormalkan status yang diminta ke nama status workflow dan memastikan
+// perpindahan dari status saat ini diizinkan. current kosong berarti task baru.
+func resolveTaskStatus(workflow models.Workflow, current, requested string) (models.WorkflowStatus, error) {
+	from, hasCurrent := workflow.FindStatus(current)
+
+	if strings.TrimSpace(requested) == "" {
+		if hasCurrent {
+			return from, nil
+		}
+		if status, ok := workflow.FirstStatus(models.StatusCategoryTodo); ok {
+			return status, nil
+		}
+		if len(workflow.Statuses) > 0 {
+			return workflow.Statuses[0], nil
+		}
+		return models.WorkflowStatus{}, ErrUnknownStatus
+	}
+
+	to, ok := workflow.FindStatus(requested)
+	if !ok {
+		return models.WorkflowStatus{}, ErrUnknownStatus.WithDetail("allowed", statusNames(workflow.Statuses))
+	}
+
+	if hasCurrent && !workflow.CanTransition(from.ID, to.ID) {
+		return models.WorkflowStatus{}, ErrIllegalTransition.
+			WithDetail("from", from.Name).
+			WithDetail("to", to.Name).
+			WithDetail("allowed", nextStatuses(workflow, from.ID))
+	}
+	return to, nil
+}
+
+func statusNames(statuses []models.WorkflowStatus) []string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = status.Name
+	}
+	return names
+}
+
+func nextStatuses(workflow models.Workflow, fromID uint) []string {
+	names := []string{}
+	for _, transition := range workflow.Transitions {
+		if transition.FromStatusID == fromID {
+			names = append(names, transition.To)
+		}
+	}
+	return names
+}
@@ -0,0 +1,255 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 adalah pengganti S3 lokal yang menyimpan object di memori dan memverifikasi signature V4
+// setiap request dengan perhitungan sendiri, bukan memakai fungsi sign milik S3Store.
+type fakeS3 struct {
+	t         *testing.T
+	region    string
+	accessKey string
+	secretKey string
+
+	mu           sync.Mutex
+	objects      map[string][]byte
+	contentTypes map[string]string
+	hosts        []string
+}
+
+func newFakeS3(t *testing.T) *fakeS3 {
+	return &fakeS3{
+		t:            t,
+		region:       "ap-southeast-1",
+		accessKey:    "AKIDEXAMPLE",
+		secretKey:    "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+		objects:      map[string][]byte{},
+		contentTypes: map[string]string{},
+	}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := f.verify(r); err != nil {
+		f.t.Errorf("%s %s: %v", r.Method, r.RequestURI, err)
+		http.Error(w, "<Error><Code>SignatureDoesNotMatch</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hosts = append(f.hosts, r.Host)
+	path := r.URL.Path
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if int64(len(body)) != r.ContentLength {
+			http.Error(w, "content length mismatch", http.StatusBadRequest)
+			return
+		}
+		f.objects[path] = body
+		f.contentTypes[path] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Write(body)
+	case http.MethodDelete:
+		if _, ok := f.objects[path]; !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (f *fakeS3) verify(r *http.Request) error {
+	amzDate := r.Header.Get("X-Amz-Date")
+	signedAt, err := time.Parse("20060102T150405Z", amzDate)
+	if err != nil {
+		return fmt.Errorf("X-Amz-Date tidak valid: %q", amzDate)
+	}
+	if d := time.Since(signedAt); d < -time.Minute || d > time.Minute {
+		return fmt.Errorf("X-Amz-Date terlalu jauh dari sekarang: %s", amzDate)
+	}
+	payload := r.Header.Get("X-Amz-Content-Sha256")
+	if payload != "UNSIGNED-PAYLOAD" {
+		return fmt.Errorf("X-Amz-Content-Sha256 = %q", payload)
+	}
+
+	date := amzDate[:8]
+	scope := date + "/" + f.region + "/s3/aws4_request"
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	prefix := "AWS4-HMAC-SHA256 Credential=" + f.accessKey + "/" + scope + ", SignedHeaders=" + signedHeaders + ", Signature="
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return fmt.Errorf("Authorization = %q, want prefix %q", auth, prefix)
+	}
+
+	path, query, _ := strings.Cut(r.RequestURI, "?")
+	canonicalRequest := r.Method + "\n" + path + "\n" + query + "\n" +
+		"host:" + r.Host + "\n" +
+		"x-amz-content-sha256:" + payload + "\n" +
+		"x-amz-date:" + amzDate + "\n" + "\n" +
+		signedHeaders + "\n" + payload
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := []byte("AWS4" + f.secretKey)
+	for _, part := range []string{date, f.region, "s3", "aws4_request"} {
+		key = sign(key, part)
+	}
+	if want := hex.EncodeToString(sign(key, stringToSign)); strings.TrimPrefix(auth, prefix) != want {
+		return fmt.Errorf("signature tidak cocok, canonical request:\n%s", canonicalRequest)
+	}
+	return nil
+}
+
+func sign(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func newTestS3Store(t *testing.T, fake *fakeS3, endpoint string, pathStyle bool) *S3Store {
+	t.Helper()
+	store, err := NewS3Store(S3Config{
+		Endpoint:  endpoint,
+		Region:    fake.region,
+		Bucket:    "attachments",
+		AccessKey: fake.accessKey,
+		SecretKey: fake.secretKey,
+		PathStyle: pathStyle,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store
+}
+
+func readAll(t *testing.T, store Store, key string) string {
+	t.Helper()
+	body, err := store.Get(key)
+	if err != nil {
+		t.Fatalf("Get(%q): %v", key, err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestS3StoreRoundTrip(t *testing.T) {
+	fake := newFakeS3(t)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	store := newTestS3Store(t, fake, server.URL, true)
+
+	// key dengan spasi dan karakter non-ASCII memastikan path di-encode sama seperti yang ditandatangani
+	keys := []string{"ab/abcdef0123456789", "laporan akhir/Rencana (v2) é.pdf"}
+	for _, key := range keys {
+		content := "isi " + key
+		if err := store.Put(key, strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+			t.Fatalf("Put(%q): %v", key, err)
+		}
+		if got := readAll(t, store, key); got != content {
+			t.Errorf("Get(%q) = %q, want %q", key, got, content)
+		}
+	}
+	if got := fake.contentTypes["/attachments/"+keys[0]]; got != "application/pdf" {
+		t.Errorf("content type = %q, want application/pdf", got)
+	}
+
+	if err := store.Delete(keys[0]); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(keys[0]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get setelah Delete = %v, want ErrNotFound", err)
+	}
+	// Delete object yang sudah tidak ada dianggap berhasil
+	if err := store.Delete(keys[0]); err != nil {
+		t.Errorf("Delete kedua = %v, want nil", err)
+	}
+}
+
+func TestS3StoreNotFound(t *testing.T) {
+	fake := newFakeS3(t)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	store := newTestS3Store(t, fake, server.URL, true)
+
+	if _, err := store.Get("tidak/ada"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get = %v, want ErrNotFound", err)
+	}
+}
+
+func TestS3StoreErrorStatus(t *testing.T) {
+	fake := newFakeS3(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+	}))
+	defer server.Close()
+	store := newTestS3Store(t, fake, server.URL, true)
+
+	err := store.Put("a", strings.NewReader("x"), 1, "")
+	if err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Put = %v, want error AccessDenied", err)
+	}
+	if err := store.Delete("a"); err == nil {
+		t.Error("Delete = nil, want error selain 404")
+	}
+}
+
+func TestS3StoreVirtualHostedStyle(t *testing.T) {
+	fake := newFakeS3(t)
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	// bucket.127.0.0.1 tidak bisa di-resolve, jadi semua koneksi diarahkan ke server test
+	addr := server.Listener.Addr().String()
+	store := newTestS3Store(t, fake, server.URL, false)
+	store.client = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+
+	if err := store.Put("ab/cd", strings.NewReader("data"), 4, ""); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if got := readAll(t, store, "ab/cd"); got != "data" {
+		t.Errorf("Get = %q, want data", got)
+	}
+	u, _ := url.Parse(server.URL)
+	if want := "attachments." + u.Host; fake.hosts[0] != want {
+		t.Errorf("host = %q, want %q", fake.hosts[0], want)
+	}
+	if _, ok := fake.objects["/ab/cd"]; !ok {
+		t.Errorf("object disimpan di %v, want /ab/cd", fake.objects)
+	}
+}
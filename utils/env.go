@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Int64FromEnv membaca angka dari environment, fallback dipakai jika kosong atau tidak valid
+func Int64FromEnv(key string, fallback int64) int64 {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil && n > 0 {
+			return n
+		}
+	}
+	return fallback
+}
+
+// ListFromEnv membaca daftar yang dipisahkan koma dari environment
+func ListFromEnv(key string, fallback []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Jenis error domain. Service mengembalikan *AppError yang membungkus salah satu dari ini
+// sehingga errors.Is(err, utils.ErrNotFound) bisa dipakai untuk menentukan status HTTP.
+var (
+	ErrNotFound             = errors.New("not found")
+	ErrForbidden            = errors.New("forbidden")
+	ErrConflict             = errors.New("conflict")
+	ErrValidation           = errors.New("validation failed")
+	ErrUnauthorized         = errors.New("unauthorized")
+	ErrPrecondition         = errors.New("precondition failed")
+	ErrPreconditionRequired = errors.New("precondition required")
+)
+
+// AppError adalah error domain dengan kode yang stabil untuk dibaca oleh client
+type AppError struct {
+	Kind    error
+	Code    string
+	Message string
+	Details map[string]interface{}
+}
+
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Kind
+}
+
+// WithDetail mengembalikan salinan error dengan informasi tambahan, sentinel aslinya tidak diubah
+func (e *AppError) WithDetail(key string, value interface{}) *AppError {
+	details := make(map[string]interface{}, len(e.Details)+1)
+	for k, v := range e.Details {
+		details[k] = v
+	}
+	details[key] = value
+
+	return &AppError{Kind: e.Kind, Code: e.Code, Message: e.Message, Details: details}
+}
+
+// Is membuat salinan dari WithDetail tetap cocok dengan sentinel asalnya
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code && t.Kind == e.Kind
+}
+
+func NotFound(code, message string) *AppError {
+	return &AppError{Kind: ErrNotFound, Code: code, Message: message}
+}
+
+func Forbidden(code, message string) *AppError {
+	return &AppError{Kind: ErrForbidden, Code: code, Message: message}
+}
+
+func Conflict(code, message string) *AppError {
+	return &AppError{Kind: ErrConflict, Code: code, Message: message}
+}
+
+func Validation(code, message string) *AppError {
+	return &AppError{Kind: ErrValidation, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *AppError {
+	return &AppError{Kind: ErrUnauthorized, Code: code, Message: message}
+}
+
+func PreconditionFailed(code, message string) *AppError {
+	return &AppError{Kind: ErrPrecondition, Code: code, Message: message}
+}
+
+func PreconditionRequired(code, message string) *AppError {
+	return &AppError{Kind: ErrPreconditionRequired, Code: code, Message: message}
+}
+
+// Problem adalah body error sesuai RFC 7807 (application/problem+json)
+type Problem struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail,omitempty"`
+	Instance string                 `json:"instance,omitempty"`
+	Code     string                 `json:"code"`
+	Details  map[string]interface{} `json:"details,omitempty"`
+}
+
+// StatusFor memetakan jenis error ke status HTTP
+func StatusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrPrecondition):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, ErrPreconditionRequired):
+		return http.StatusPreconditionRequired
+	}
+	return http.StatusInternalServerError
+}
+
+// NewProblem membangun Problem dari error. Error yang bukan AppError dianggap internal
+// dan pesannya tidak dikirim ke client.
+func NewProblem(err error) Problem {
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		return Problem{
+			Type:   "/problems/internal_error",
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError,
+			Detail: "Internal server error",
+			Code:   "internal_error",
+		}
+	}
+
+	status := StatusFor(appErr)
+	return Problem{
+		Type:    "/problems/" + appErr.Code,
+		Title:   http.StatusText(status),
+		Status:  status,
+		Detail:  appErr.Message,
+		Code:    appErr.Code,
+		Details: appErr.Details,
+	}
+}
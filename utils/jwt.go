@@ -1,37 +1,85 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
 	"time"
 	"os"
 	"log"
 	"errors"
+	"io/fs"
 	"PA/models"
-	
+
 	"github.com/dgrijalva/jwt-go"
 	"github.com/joho/godotenv"
 )
 
+// .env boleh tidak ada (misalnya saat go test), variabel juga bisa diset langsung di environment
 func init() {
-	if err := godotenv.Load(); err != nil {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		log.Fatal("Error tidak dapat membaca .env")
 	}
 }
 
 var SecretKey = []byte(os.Getenv("JWT_SECRET_KEY"))
 
-func GenerateJWT(user models.User) (string, error) {
+// Masa berlaku access token dibuat pendek, sesi diperpanjang lewat refresh token
+var AccessTokenTTL = durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute)
+var RefreshTokenTTL = durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return fallback
+}
+
+// TokenClaims berisi informasi sesi yang dibawa access token
+type TokenClaims struct {
+	ID        string
+	FamilyID  string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+func GenerateJWT(user models.User, familyID string) (string, TokenClaims, error) {
+    jti, err := randomHex(16)
+    if err != nil {
+        return "", TokenClaims{}, err
+    }
+
+    now := time.Now()
+    session := TokenClaims{
+        ID:        jti,
+        FamilyID:  familyID,
+        IssuedAt:  now.Truncate(time.Millisecond),
+        ExpiresAt: now.Add(AccessTokenTTL),
+    }
+
     claims := jwt.MapClaims{
         "id":       user.ID,
         "username": user.Username,
-        "exp":      time.Now().Add(time.Hour * 72).Unix(),
+        "jti":      session.ID,
+        "fid":      session.FamilyID,
+        // iat dalam milidetik agar login ulang sesaat setelah logout semua sesi tidak ikut tertolak
+        "iat":      float64(session.IssuedAt.UnixMilli()) / 1000,
+        "exp":      session.ExpiresAt.Unix(),
     }
 
     token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-    return token.SignedString(SecretKey)
+    signed, err := token.SignedString(SecretKey)
+    return signed, session, err
 }
 
 func ParseJWT(tokenString string) (*jwt.Token, *models.User, error) {
     token, err := jwt.ParseWithClaims(tokenString, jwt.MapClaims{}, func(token *jwt.Token) (interface{}, error) {
+        if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+            return nil, errors.New("unexpected signing method")
+        }
         return SecretKey, nil
     })
 
@@ -41,13 +89,63 @@ func ParseJWT(tokenString string) (*jwt.Token, *models.User, error) {
 
     if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
         if id, ok := claims["id"].(float64); ok {
+            username, _ := claims["username"].(string)
             user := &models.User{
                 ID:       uint(id),
-                Username: claims["username"].(string),
+                Username: username,
             }
             return token, user, nil
         }
     }
 
     return nil, nil, errors.New("invalid token claims")
-}
\ No newline at end of file
+}
+
+// GetTokenClaims mengambil jti, family dan waktu kadaluarsa dari token yang sudah diparse
+func GetTokenClaims(token *jwt.Token) (TokenClaims, error) {
+    claims, ok := token.Claims.(jwt.MapClaims)
+    if !ok {
+        return TokenClaims{}, errors.New("invalid token claims")
+    }
+
+    jti, _ := claims["jti"].(string)
+    if jti == "" {
+        return TokenClaims{}, errors.New("token tidak memiliki jti")
+    }
+    familyID, _ := claims["fid"].(string)
+    exp, _ := claims["exp"].(float64)
+    iat, _ := claims["iat"].(float64)
+
+    return TokenClaims{
+        ID:        jti,
+        FamilyID:  familyID,
+        IssuedAt:  time.UnixMilli(int64(math.Round(iat * 1000))),
+        ExpiresAt: time.Unix(int64(exp), 0),
+    }, nil
+}
+
+// GenerateRefreshToken menghasilkan refresh token acak beserta hash yang disimpan di database
+func GenerateRefreshToken() (string, string, error) {
+    token, err := randomHex(32)
+    if err != nil {
+        return "", "", err
+    }
+    return token, HashToken(token), nil
+}
+
+func GenerateTokenFamily() (string, error) {
+    return randomHex(16)
+}
+
+func HashToken(token string) string {
+    sum := sha256.Sum256([]byte(token))
+    return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+    b := make([]byte, n)
+    if _, err := rand.Read(b); err != nil {
+        return "", err
+    }
+    return hex.EncodeToString(b), nil
+}